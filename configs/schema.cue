@@ -1,16 +1,106 @@
 #Tuple: 3 * [number]
 
 #Camera: {
+  type?: "perspective" | "orthographic" | "fisheye" | "equirectangular"
 	width: number
 	height: number
 	fov: number
 	from: #Tuple
   to: #Tuple
   up: #Tuple
+  aperture?: number & >=0
+  focal_distance?: number & >0
+  view_width?: number & >0
+  // the orthographic projection has no field of view, the width of the visible area is needed instead.
+  if type != _|_ {
+    if type == "orthographic" {
+      view_width: number & >0
+    }
+  }
 }
-#Light: {
+#Render: {
+  samples?: int & >=1
+  sampler?: "stratified" | "jittered"
+  filter?: "box" | "tent" | "gaussian" | "mitchell"
+  adaptive?: #Adaptive
+  integrator?: "whitted" | "path"
+  max_depth?: int & >=0
+  roulette_depth?: int & >=0
+  passes?: [...("depth" | "normal" | "albedo" | "object_id" | "direct" | "indirect" | "reflection" | "refraction")]
+}
+
+#Post: {
+  exposure?: number
+  tonemap?: "none" | "reinhard" | "aces" | "uncharted2"
+  srgb?: bool
+}
+
+#Adaptive: {
+  min_samples: int & >=2
+  max_samples: int & >=min_samples
+  threshold: number & >0
+  heatmap?: bool
+}
+
+#PointLight: {
+  type?: "point"
+  position: #Tuple
+  intensity: #Tuple
+  attenuation?: #Attenuation
+  casts_shadows?: bool
+}
+
+#RectLight: {
+  type: "rect"
+  position: #Tuple
+  intensity: #Tuple
+  u: #Tuple
+  v: #Tuple
+  samples?: int & >=1
+  attenuation?: #Attenuation
+  casts_shadows?: bool
+}
+
+#DiskLight: {
+  type: "disk"
+  position: #Tuple
+  intensity: #Tuple
+  normal: #Tuple
+  radius: number & >0
+  samples?: int & >=1
+  attenuation?: #Attenuation
+  casts_shadows?: bool
+}
+
+#DirectionalLight: {
+  type: "directional"
+  direction: #Tuple
+  intensity: #Tuple
+  casts_shadows?: bool
+}
+
+#SpotLight: {
+  type: "spot"
   position: #Tuple
+  direction: #Tuple
   intensity: #Tuple
+  inner_angle: number & >=0
+  outer_angle: number & >=inner_angle
+  attenuation?: #Attenuation
+  casts_shadows?: bool
+}
+
+#Attenuation: {
+  type: "none" | "inverse-square"
+} | {
+  type: "polynomial"
+  constant?: number & >=0
+  linear?: number & >=0
+  quadratic?: number & >=0
+}
+
+#Light: {
+  *#PointLight | #RectLight | #DiskLight | #DirectionalLight | #SpotLight
 }
 
 #Lights: {
@@ -42,8 +132,39 @@
 
 #pattern: {
   type: string
-  colors: [...#Tuple]
+  colors?: [...#Tuple]
   transform?: #transform
+  mapping?: "spherical" | "planar" | "cylindrical" | "cube" | "vertex"
+  file?: string
+  filter?: "nearest" | "bilinear"
+  wrap?: "repeat" | "clamp"
+  srgb?: bool
+  width?: number & >0
+  height?: number & >0
+  weight?: number & >=0 & <=1
+  noise?: #noise
+  perturb?: {
+    amount: number & >=0
+    noise?: #noise
+  }
+  // stripes, gradients, rings and checkers take two colors or two patterns, the patterns combining other patterns need their inputs.
+  if type == "stripe" || type == "gradient" || type == "ring" || type == "checker" {
+    patterns?: [#pattern, #pattern]
+  }
+  if type == "blend" || type == "mask" || type == "add" || type == "subtract" || type == "multiply" || type == "min" || type == "max" {
+    patterns: [#pattern, #pattern]
+  }
+  if type == "mask" {
+    mask: #pattern
+  }
+}
+
+#noise: {
+  type?: "perlin" | "simplex"
+  octaves?: int & >=1
+  lacunarity?: number & >0
+  gain?: number & >0
+  seed?: int & >=0
 }
 
 #material: {
@@ -56,24 +177,45 @@
   reflective: number
   transparency: number
   refractive_index: number
+  emission?: #Tuple
+  emission_strength?: number & >=0
+  bsdf?: #bsdf
+  bump?: {
+    type?: "height" | "normal"
+    strength?: number
+    pattern: #pattern
+  }
+}
+
+#bsdf: {
+  type: "phong" | "ggx"
+  roughness?: number & >=0 & <=1
+  metallic?: number & >=0 & <=1
+  f0?: number & >=0 & <=1
 }
 
 #Sphere: {
   type: string & "sphere"
   transform?: #transform
   material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
 }
 
 #Cube: {
   type: "cube"
   transform?: #transform
   material?: #material 
+  casts_shadow?: bool
+  receives_shadow?: bool
 }
 
 #Plane: {
   type: "plane"
   transform?: #transform
   material?: #material 
+  casts_shadow?: bool
+  receives_shadow?: bool
 }
 
 #Cylinder: {
@@ -83,6 +225,85 @@
   maximum: number
   closed: bool
   material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#Cone: {
+  type: "cone"
+  transform?: #transform
+  minimum: number
+  maximum: number
+  closed: bool
+  material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#Torus: {
+  type: "torus"
+  transform?: #transform
+  major_radius?: number & >0
+  minor_radius?: number & >0
+  material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#Disk: {
+  type: "disk"
+  transform?: #transform
+  inner_radius?: number & >=0 & <1
+  material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#Quad: {
+  type: "quad"
+  transform?: #transform
+  material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#sdf: {
+  type: "sphere" | "rounded_box" | "capsule" | "torus" | "union" | "subtract" | "intersection"
+  radius?: number & >=0
+  size?: #Tuple
+  from?: #Tuple
+  to?: #Tuple
+  major_radius?: number & >0
+  minor_radius?: number & >0
+  children?: [#sdf, #sdf, ...#sdf]
+  smoothness?: number & >=0
+  offset?: #Tuple
+  repeat?: [number & >=0, number & >=0, number & >=0]
+}
+
+#SDF: {
+  type: "sdf"
+  sdf: #sdf
+  transform?: #transform
+  material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#Heightfield: {
+  type: "heightfield"
+  transform?: #transform
+  material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+  // the heights come either from an image or from noise.
+  {
+    file: string
+  } | {
+    noise!: #noise
+    resolution?: int & >=2
+    frequency?: number & >0
+  }
 }
 
 #Model: {
@@ -90,6 +311,9 @@
   file: string
   transform?: #transform
   material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+  light_samples?: int & >=1
 }
 
 #Group: {
@@ -97,12 +321,26 @@
   children: [...#Objects]
   transform?: #transform
   material?: #material
+  casts_shadow?: bool
+  receives_shadow?: bool
+}
+
+#CSG: {
+  type: "csg"
+  operation: "union" | "intersection" | "difference"
+  left: #Objects
+  right: #Objects
+  transform?: #transform
+  casts_shadow?: bool
+  receives_shadow?: bool
 }
 
 #Objects: {
-  #Sphere | #Cube | #Plane | #Cylinder | #Model | #Group
+  #Sphere | #Cube | #Plane | #Cylinder | #Cone | #Torus | #Disk | #Quad | #SDF | #Heightfield | #Model | #Group | #CSG
 }
 
 camera: #Camera
+render?: #Render
+post?: #Post
 lights: #Lights
 objects: [...#Objects]
@@ -13,17 +13,24 @@ type Camera struct {
 	Width, Height int     // the with and height of the image in pixels.
 	Fov           float64 // An angle that describes how much the camera can see.
 	// when the value is small it the view will be zoomed in.
+	Aperture      float64 // diameter of the lens, 0 is a pinhole camera where everything is in focus.
+	FocalDistance float64 // distance from the camera to the plane that is in perfect focus.
+
 	pixelSize             float64       // the size of a pixel in world units.
 	halfWidth, halfHeight float64       // helper variables to avoid repeated calculations.
 	transform             matrix.Matrix // transformation matrix to position the camera.
+	inverse               matrix.Matrix // inverse of the transform, cached because every ray needs it.
+	projection            Projection
 }
 
 func New(width, height int, fov float64) *Camera {
 	c := Camera{
-		Width:     width,
-		Height:    height,
-		Fov:       fov,
-		transform: matrix.DefaultTransform(),
+		Width:      width,
+		Height:     height,
+		Fov:        fov,
+		transform:  matrix.DefaultTransform(),
+		inverse:    matrix.DefaultTransform(),
+		projection: Perspective{},
 	}
 
 	halfView := math.Tan(fov / 2.0)
@@ -47,29 +54,44 @@ func (c *Camera) Transform() matrix.Matrix {
 
 func (c *Camera) SetTransform(m matrix.Matrix) {
 	c.transform = m
+	c.inverse = m.Inverse()
+}
+
+func (c *Camera) Projection() Projection {
+	return c.projection
+}
+
+// SetProjection changes how the canvas is projected into the scene, cameras are perspective by default.
+func (c *Camera) SetProjection(p Projection) {
+	c.projection = p
 }
 
-// RayForPixel computes the ray that passes through the camera pixel (x, y).
+// RayForPixel computes the ray that passes through the center of the camera pixel (x, y).
 // TODO: move to the renderer package
 func (c *Camera) RayForPixel(x, y int) *ray.Ray {
-	// the offset from the edge of the canvas to the pixel's center
-	xOffset := (float64(x) + 0.5) * c.pixelSize
-	yOffset := (float64(y) + 0.5) * c.pixelSize
-
-	// the untransformed coordinates of the pixel in global space.
-	// the camera looks toward -z, so +x is to the left.
-	sceneX := c.halfWidth - xOffset
-	sceneY := c.halfHeight - yOffset
+	return c.RayAt(float64(x)+0.5, float64(y)+0.5)
+}
 
-	invTransform := c.transform.Inverse()
+// RayAt computes the ray that passes through the point (px, py) of the canvas, measured in pixels
+// from the top left corner. Any point inside a pixel can be targeted, which is used for supersampling.
+// The ray starts from the center of the lens.
+func (c *Camera) RayAt(px, py float64) *ray.Ray {
+	return c.RayThroughLens(px, py, 0.5, 0.5)
+}
 
-	// using the camera matrix, transform the canvas point and the origin,
-	// and then compute the ray's direction vector. The canvas is at z=-1
-	pixel := tuple.Multiply(invTransform, tuple.NewPoint(sceneX, sceneY, -1))
-	origin := tuple.Multiply(invTransform, tuple.NewPoint(0, 0, 0))
-	direction := tuple.Subtract(pixel, origin).Normalize()
+// RayThroughLens computes the ray that starts from the point (lu, lv) of the lens and passes through
+// the point (px, py) of the canvas. lu and lv are between 0 and 1 and are mapped onto the disk of the lens.
+// Every ray through the same canvas point meets on the focal plane, objects in front of
+// or behind it are blurred, the larger the aperture the more.
+// Returns nil when the point is outside of the image of the projection, like the corners of a fisheye image.
+func (c *Camera) RayThroughLens(px, py, lu, lv float64) *ray.Ray {
+	origin, direction, ok := c.projection.Ray(c, px, py, lu, lv)
+	if !ok {
+		return nil
+	}
 
-	return ray.New(origin, direction)
+	// using the camera matrix, transform the ray from camera space to the scene.
+	return ray.New(tuple.Multiply(c.inverse, origin), tuple.Multiply(c.inverse, direction).Normalize())
 }
 
 // Transforms the camera position,
@@ -131,3 +131,46 @@ func TestViewTransformation(t *testing.T) {
 		}
 	}
 }
+
+func TestRayAt(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+
+	// The center of a pixel is the same as RayForPixel
+	if result, expected := c.RayAt(100.5, 50.5), c.RayForPixel(100, 50); !result.Equal(expected) {
+		t.Errorf("RayAt expected %s, got %s", expected, result)
+	}
+
+	// The top left corner of the canvas
+	expected := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(1, 101.0/201.0, -1).Normalize())
+	if result := c.RayAt(0, 0); !result.Equal(expected) {
+		t.Errorf("RayAt expected %s, got %s", expected, result)
+	}
+}
+
+func TestRayThroughLens(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	c.SetTransform(ViewTransformation(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	// The center of the lens is the same as a pinhole camera
+	c.Aperture, c.FocalDistance = 0.5, 5
+	if result, expected := c.RayThroughLens(40.5, 20.5, 0.5, 0.5), c.RayAt(40.5, 20.5); !result.Equal(expected) {
+		t.Errorf("RayThroughLens expected %s, got %s", expected, result)
+	}
+
+	// Rays from the edges of the lens start from different points and meet on the focal plane
+	focus := tuple.NewPoint(0, 0, 0)
+	for _, lens := range [][2]float64{{0, 0.5}, {1, 0.5}, {0.5, 0}, {0.2, 0.9}} {
+		r := c.RayThroughLens(100.5, 50.5, lens[0], lens[1])
+		if utils.FloatEquals(r.Origin.X, 0) && utils.FloatEquals(r.Origin.Y, 0) {
+			t.Errorf("RayThroughLens(%f, %f) expected the ray to start off center, got %s", lens[0], lens[1], r.Origin)
+		}
+		if math.Hypot(r.Origin.X, r.Origin.Y) > c.Aperture/2+utils.EPSILON {
+			t.Errorf("RayThroughLens(%f, %f) expected the ray to start on the lens, got %s", lens[0], lens[1], r.Origin)
+		}
+		d := -r.Origin.Z / r.Direction.Z
+		hit := tuple.Add(r.Origin, r.Direction.Scalar(d))
+		if !hit.Equal(focus) {
+			t.Errorf("RayThroughLens(%f, %f) expected the ray to pass through %s, got %s", lens[0], lens[1], focus, hit)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package camera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// Projection maps the points of the canvas to rays leaving the camera.
+// The rays are in camera space, where the camera is at the origin looking toward -z,
+// the view transformation of the camera places them in the scene.
+type Projection interface {
+	// Ray returns the origin and direction of the ray through the point (px, py) of the canvas,
+	// measured in pixels from the top left corner. (lu, lv) is a point on the lens, used for depth of field.
+	// ok is false when the point is outside of the projected image.
+	Ray(c *Camera, px, py, lu, lv float64) (origin, direction tuple.Tuple, ok bool)
+}
+
+// Perspective is a pinhole or thin lens camera, fov is the angle of view along the longer side of the canvas.
+type Perspective struct{}
+
+func (p Perspective) String() string {
+	return "perspective"
+}
+
+func (p Perspective) Ray(c *Camera, px, py, lu, lv float64) (origin, direction tuple.Tuple, ok bool) {
+	// the offset from the edge of the canvas to the point
+	xOffset := px * c.pixelSize
+	yOffset := py * c.pixelSize
+
+	// the untransformed coordinates of the point in global space.
+	// the camera looks toward -z, so +x is to the left.
+	sceneX := c.halfWidth - xOffset
+	sceneY := c.halfHeight - yOffset
+
+	// The canvas is at z=-1
+	if c.Aperture <= 0 || c.FocalDistance <= 0 {
+		return tuple.NewPoint(0, 0, 0), tuple.NewVector(sceneX, sceneY, -1).Normalize(), true
+	}
+
+	// the ray through the center of the lens is not bent, the point it reaches on the focal plane is in focus.
+	focus := tuple.NewPoint(sceneX*c.FocalDistance, sceneY*c.FocalDistance, -c.FocalDistance)
+	x, y := utils.ConcentricDisk(lu, lv)
+	lens := tuple.NewPoint(x*c.Aperture/2, y*c.Aperture/2, 0)
+
+	return lens, tuple.Subtract(focus, lens).Normalize(), true
+}
+
+// Orthographic sends parallel rays, objects keep their size regardless of their distance.
+// ViewWidth is the width of the visible area in world units.
+type Orthographic struct {
+	ViewWidth float64
+}
+
+func (p Orthographic) String() string {
+	return fmt.Sprintf("orthographic(view width: %f)", p.ViewWidth)
+}
+
+func (p Orthographic) Ray(c *Camera, px, py, lu, lv float64) (origin, direction tuple.Tuple, ok bool) {
+	pixelSize := p.ViewWidth / float64(c.Width)
+	x := p.ViewWidth/2 - px*pixelSize
+	y := pixelSize*float64(c.Height)/2 - py*pixelSize
+
+	return tuple.NewPoint(x, y, 0), tuple.NewVector(0, 0, -1), true
+}
+
+// Fisheye is an equidistant fisheye lens, the angle from the view direction grows linearly with
+// the distance from the center of the image. The image is a circle that fits the shorter side of the canvas,
+// fov is the angle of view across the circle and can be larger than 180°.
+type Fisheye struct{}
+
+func (p Fisheye) String() string {
+	return "fisheye"
+}
+
+func (p Fisheye) Ray(c *Camera, px, py, lu, lv float64) (origin, direction tuple.Tuple, ok bool) {
+	radius := float64(min(c.Width, c.Height)) / 2
+	// the point relative to the center of the circle, +x is to the left.
+	x := (float64(c.Width)/2 - px) / radius
+	y := (float64(c.Height)/2 - py) / radius
+	r := math.Hypot(x, y)
+	if r > 1 {
+		return tuple.Tuple{}, tuple.Tuple{}, false
+	}
+
+	theta := r * c.Fov / 2
+	if r == 0 {
+		return tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, -1), true
+	}
+	sin := math.Sin(theta)
+	return tuple.NewPoint(0, 0, 0), tuple.NewVector(sin*x/r, sin*y/r, -math.Cos(theta)), true
+}
+
+// Equirectangular is a 360° panorama, the horizontal axis of the canvas is the longitude
+// and the vertical axis is the latitude. The center of the image is the view direction.
+// A canvas twice as wide as tall keeps the pixels square.
+type Equirectangular struct{}
+
+func (p Equirectangular) String() string {
+	return "equirectangular"
+}
+
+func (p Equirectangular) Ray(c *Camera, px, py, lu, lv float64) (origin, direction tuple.Tuple, ok bool) {
+	longitude := (px/float64(c.Width) - 0.5) * 2 * math.Pi
+	latitude := (0.5 - py/float64(c.Height)) * math.Pi
+
+	// the camera looks toward -z and +x is to the left.
+	return tuple.NewPoint(0, 0, 0), tuple.NewVector(
+		-math.Sin(longitude)*math.Cos(latitude),
+		math.Sin(latitude),
+		-math.Cos(longitude)*math.Cos(latitude),
+	), true
+}
+
+// ParseProjection converts the name of a projection into a Projection.
+// viewWidth is only used by the orthographic projection, where it must be above 0.
+func ParseProjection(name string, viewWidth float64) (Projection, error) {
+	switch name {
+	case "", "perspective":
+		return Perspective{}, nil
+	case "orthographic":
+		if viewWidth <= 0 {
+			return nil, fmt.Errorf("orthographic projection needs a view width above 0, got %f", viewWidth)
+		}
+		return Orthographic{ViewWidth: viewWidth}, nil
+	case "fisheye":
+		return Fisheye{}, nil
+	case "equirectangular":
+		return Equirectangular{}, nil
+	default:
+		return nil, fmt.Errorf("unknown projection: %s", name)
+	}
+}
@@ -0,0 +1,121 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+func TestProjections(t *testing.T) {
+	ortho := New(200, 100, math.Pi/2)
+	ortho.SetProjection(Orthographic{ViewWidth: 10})
+	fisheye := New(100, 100, math.Pi)
+	fisheye.SetProjection(Fisheye{})
+	panorama := New(200, 100, math.Pi/2)
+	panorama.SetProjection(Equirectangular{})
+
+	var tests = []struct {
+		name              string
+		c                 *Camera
+		px, py            float64
+		origin, direction tuple.Tuple
+	}{
+		{
+			name: "orthographic center", c: ortho, px: 100, py: 50,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(0, 0, -1),
+		},
+		{
+			// the rays are parallel, only the origin moves.
+			name: "orthographic corner", c: ortho, px: 0, py: 0,
+			origin: tuple.NewPoint(5, 2.5, 0), direction: tuple.NewVector(0, 0, -1),
+		},
+		{
+			name: "fisheye center", c: fisheye, px: 50, py: 50,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(0, 0, -1),
+		},
+		{
+			// with a 180° fisheye the edge of the circle looks sideways.
+			name: "fisheye edge", c: fisheye, px: 0, py: 50,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(1, 0, 0),
+		},
+		{
+			name: "fisheye halfway", c: fisheye, px: 50, py: 25,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(0, math.Sqrt(2)/2, -math.Sqrt(2)/2),
+		},
+		{
+			name: "equirectangular center", c: panorama, px: 100, py: 50,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(0, 0, -1),
+		},
+		{
+			name: "equirectangular left", c: panorama, px: 50, py: 50,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(1, 0, 0),
+		},
+		{
+			name: "equirectangular behind", c: panorama, px: 0, py: 50,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(0, 0, 1),
+		},
+		{
+			name: "equirectangular top", c: panorama, px: 100, py: 0,
+			origin: tuple.NewPoint(0, 0, 0), direction: tuple.NewVector(0, 1, 0),
+		},
+	}
+
+	for _, test := range tests {
+		r := test.c.RayAt(test.px, test.py)
+		if r == nil {
+			t.Errorf("%s: expected a ray, got nil", test.name)
+			continue
+		}
+		if !r.Origin.Equal(test.origin) || !r.Direction.Equal(test.direction) {
+			t.Errorf("%s: expected origin %s and direction %s, got %s", test.name, test.origin, test.direction, r)
+		}
+	}
+
+	// The corners of a fisheye image are outside of the circle
+	if r := fisheye.RayAt(0, 0); r != nil {
+		t.Errorf("fisheye corner expected no ray, got %s", r)
+	}
+}
+
+func TestProjectionTransform(t *testing.T) {
+	// Every projection is placed by the view transformation
+	c := New(200, 100, math.Pi/2)
+	c.SetTransform(ViewTransformation(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	for _, p := range []Projection{Perspective{}, Orthographic{ViewWidth: 4}, Fisheye{}, Equirectangular{}} {
+		c.SetProjection(p)
+		r := c.RayAt(100, 50)
+		if !r.Origin.Equal(tuple.NewPoint(0, 0, -5)) || !r.Direction.Equal(tuple.NewVector(0, 0, 1)) {
+			t.Errorf("%s center ray expected to start at the camera and look at the target, got %s", p, r)
+		}
+	}
+}
+
+func TestParseProjection(t *testing.T) {
+	var tests = []struct {
+		name      string
+		viewWidth float64
+		expected  Projection
+		err       bool
+	}{
+		{name: "", expected: Perspective{}},
+		{name: "perspective", expected: Perspective{}},
+		{name: "orthographic", viewWidth: 3, expected: Orthographic{ViewWidth: 3}},
+		// every pixel would get the same ray
+		{name: "orthographic", viewWidth: 0, err: true},
+		{name: "orthographic", viewWidth: -2, err: true},
+		{name: "fisheye", expected: Fisheye{}},
+		{name: "equirectangular", expected: Equirectangular{}},
+		{name: "cylindrical", err: true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseProjection(test.name, test.viewWidth)
+		if test.err && err == nil {
+			t.Errorf("ParseProjection(%s) expected an error", test.name)
+		}
+		if !test.err && result != test.expected {
+			t.Errorf("ParseProjection(%s) expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
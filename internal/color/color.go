@@ -39,6 +39,11 @@ func (c Color) Scalar(s float64) Color {
 	return Color{c.R * s, c.G * s, c.B * s}
 }
 
+// Luminance is the perceived brightness of the color, using the Rec. 709 weights.
+func (c Color) Luminance() float64 {
+	return 0.2126*c.R + 0.7152*c.G + 0.0722*c.B
+}
+
 func Add(a, b Color) Color {
 	return Color{a.R + b.R, a.G + b.G, a.B + b.B}
 }
@@ -1,6 +1,10 @@
 package color
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
 
 func TestAdd(t *testing.T) {
 	var tests = []struct {
@@ -101,3 +105,22 @@ func TestHadamardProduct(t *testing.T) {
 		}
 	}
 }
+
+func TestLuminance(t *testing.T) {
+	var tests = []struct {
+		input    Color
+		expected float64
+	}{
+		{input: White(), expected: 1},
+		{input: Black(), expected: 0},
+		{input: Red(), expected: 0.2126},
+		{input: Green(), expected: 0.7152},
+		{input: Blue(), expected: 0.0722},
+	}
+
+	for _, test := range tests {
+		if got := test.input.Luminance(); !utils.FloatEquals(got, test.expected) {
+			t.Errorf("luminance of %s \ngot: %f. \nexpected: %f", test.input, got, test.expected)
+		}
+	}
+}
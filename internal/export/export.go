@@ -1,9 +1,11 @@
-// Exports the finished canvas into a PPM file.
+// Exports the finished canvas into image files.
+// The format is picked by the extension of the file, see Save.
 package export
 
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"math"
 
 	"github.com/kaizencodes/glimpse/internal/canvas"
@@ -15,6 +17,7 @@ const (
 	PpmFormat = "P3"
 )
 
+// Export encodes the canvas as an ASCII P3 PPM image.
 func Export(c canvas.Canvas) []byte {
 	var result bytes.Buffer
 	result.WriteString(header(c))
@@ -29,6 +32,13 @@ func Export(c canvas.Canvas) []byte {
 	return result.Bytes()
 }
 
+// WriteP3 writes the canvas as an ASCII P3 PPM image.
+// It's readable as text, but much larger and slower than the binary formats.
+func WriteP3(w io.Writer, c canvas.Canvas) error {
+	_, err := w.Write(Export(c))
+	return err
+}
+
 func header(c canvas.Canvas) string {
 	return fmt.Sprintf("%s\n%d %d\n%d\n", PpmFormat, len(c), len((c)[0]), PpmMax)
 }
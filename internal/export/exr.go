@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+)
+
+// Layer is a named image inside a multi-layer file.
+// The layer without a name holds the main image, compositing tools show it by default.
+type Layer struct {
+	Name   string
+	Canvas canvas.Canvas
+}
+
+// WriteEXR writes the canvas as an OpenEXR image with 32 bit float R, G and B channels.
+// Like PFM the colors are not clamped.
+func WriteEXR(w io.Writer, c canvas.Canvas) error {
+	return WriteEXRLayers(w, []Layer{{Canvas: c}})
+}
+
+// WriteEXRLayers writes every layer into a single OpenEXR image, the channels of a named layer
+// are prefixed by the name, e.g. "depth.R". The layers have to be the same size.
+// The file is a single part scanline image without compression, every tool can read it.
+func WriteEXRLayers(w io.Writer, layers []Layer) error {
+	if len(layers) == 0 {
+		return errors.New("no layers to write")
+	}
+	width, height := len(layers[0].Canvas), len(layers[0].Canvas[0])
+
+	type channel struct {
+		name  string
+		value func(x, y int) float64
+	}
+	var channels []channel
+	for _, l := range layers {
+		if len(l.Canvas) != width || len(l.Canvas[0]) != height {
+			return errors.New("layers have different sizes")
+		}
+		prefix := ""
+		if l.Name != "" {
+			prefix = l.Name + "."
+		}
+		c := l.Canvas
+		channels = append(channels,
+			channel{prefix + "R", func(x, y int) float64 { return c[x][y].R }},
+			channel{prefix + "G", func(x, y int) float64 { return c[x][y].G }},
+			channel{prefix + "B", func(x, y int) float64 { return c[x][y].B }},
+		)
+	}
+	// readers expect the channels in alphabetical order, both in the header and the pixel data.
+	sort.Slice(channels, func(i, j int) bool { return channels[i].name < channels[j].name })
+
+	var header bytes.Buffer
+	le := binary.LittleEndian
+	header.Write([]byte{0x76, 0x2f, 0x31, 0x01}) // magic number
+	version := uint32(2)
+	for _, ch := range channels {
+		// names longer than 31 bytes need the long names flag.
+		if len(ch.name) > 31 {
+			version |= 0x400
+		}
+	}
+	binary.Write(&header, le, version)
+
+	var chlist bytes.Buffer
+	for _, ch := range channels {
+		chlist.WriteString(ch.name)
+		chlist.WriteByte(0)
+		binary.Write(&chlist, le, int32(2))       // pixel type: FLOAT
+		chlist.Write([]byte{0, 0, 0, 0})          // pLinear and reserved
+		binary.Write(&chlist, le, [2]int32{1, 1}) // x and y sampling
+	}
+	chlist.WriteByte(0)
+
+	window := [4]int32{0, 0, int32(width - 1), int32(height - 1)}
+	attribute(&header, "channels", "chlist", chlist.Bytes())
+	attribute(&header, "compression", "compression", []byte{0})
+	attribute(&header, "dataWindow", "box2i", binaryBytes(window))
+	attribute(&header, "displayWindow", "box2i", binaryBytes(window))
+	attribute(&header, "lineOrder", "lineOrder", []byte{0})
+	attribute(&header, "pixelAspectRatio", "float", binaryBytes(float32(1)))
+	attribute(&header, "screenWindowCenter", "v2f", binaryBytes([2]float32{0, 0}))
+	attribute(&header, "screenWindowWidth", "float", binaryBytes(float32(1)))
+	header.WriteByte(0)
+
+	buf := bufio.NewWriter(w)
+	buf.Write(header.Bytes())
+
+	// the offset table points to every scanline, they follow right after it.
+	lineSize := width * len(channels) * 4
+	offset := uint64(header.Len() + height*8)
+	for y := 0; y < height; y++ {
+		binary.Write(buf, le, offset)
+		offset += uint64(8 + lineSize)
+	}
+
+	var value [4]byte
+	for y := 0; y < height; y++ {
+		binary.Write(buf, le, [2]int32{int32(y), int32(lineSize)})
+		for _, ch := range channels {
+			for x := 0; x < width; x++ {
+				le.PutUint32(value[:], math.Float32bits(float32(ch.value(x, y))))
+				buf.Write(value[:])
+			}
+		}
+	}
+	return buf.Flush()
+}
+
+// writes a header attribute, the name and type are null terminated and followed by the size of the value.
+func attribute(header *bytes.Buffer, name, kind string, value []byte) {
+	header.WriteString(name)
+	header.WriteByte(0)
+	header.WriteString(kind)
+	header.WriteByte(0)
+	binary.Write(header, binary.LittleEndian, int32(len(value)))
+	header.Write(value)
+}
+
+func binaryBytes(v any) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, v)
+	return b.Bytes()
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// reads the header of an OpenEXR image, returns the channel names and the position of the offset table.
+func readEXRHeader(t *testing.T, data []byte) ([]string, int) {
+	if !bytes.HasPrefix(data, []byte{0x76, 0x2f, 0x31, 0x01, 2, 0, 0, 0}) {
+		t.Fatalf("expected the OpenEXR magic number and version, got %v", data[:8])
+	}
+
+	var channels []string
+	i := 8
+	for data[i] != 0 {
+		name, rest, _ := bytes.Cut(data[i:], []byte{0})
+		_, rest, _ = bytes.Cut(rest, []byte{0})
+		size := int(binary.LittleEndian.Uint32(rest))
+		value := rest[4 : 4+size]
+		if string(name) == "channels" {
+			for value[0] != 0 {
+				channel, after, _ := bytes.Cut(value, []byte{0})
+				channels = append(channels, string(channel))
+				value = after[16:]
+			}
+		}
+		i = len(data) - len(rest) + 4 + size
+	}
+	return channels, i + 1
+}
+
+func TestWriteEXR(t *testing.T) {
+	var result bytes.Buffer
+	if err := WriteEXR(&result, testCanvas()); err != nil {
+		t.Fatalf("WriteEXR returned an error: %s", err)
+	}
+	data := result.Bytes()
+
+	channels, table := readEXRHeader(t, data)
+	expected := []string{"B", "G", "R"}
+	if len(channels) != len(expected) {
+		t.Fatalf("WriteEXR expected channels %v, got %v", expected, channels)
+	}
+	for i := range expected {
+		if channels[i] != expected[i] {
+			t.Errorf("WriteEXR expected channels %v, got %v", expected, channels)
+		}
+	}
+
+	// every scanline has its y, its size and the channels one after the other.
+	lineSize := 2 * 3 * 4
+	if len(data) != table+3*8+3*(8+lineSize) {
+		t.Fatalf("WriteEXR expected %d bytes, got %d", table+3*8+3*(8+lineSize), len(data))
+	}
+	for y := 0; y < 3; y++ {
+		offset := int(binary.LittleEndian.Uint64(data[table+y*8:]))
+		if line := int(binary.LittleEndian.Uint32(data[offset:])); line != y {
+			t.Errorf("WriteEXR offset %d expected to point to line %d, got %d", y, y, line)
+		}
+	}
+
+	// the first line is the top of the image, the values are not clamped.
+	first := int(binary.LittleEndian.Uint64(data[table:])) + 8
+	value := func(i int) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[first+i*4:]))
+	}
+	// B of both pixels, then G, then R.
+	values := []float32{0, 0, 0, 0.5, 1.5, 0}
+	for i, e := range values {
+		if value(i) != e {
+			t.Errorf("WriteEXR value %d expected %f, got %f", i, e, value(i))
+		}
+	}
+}
+
+func TestWriteEXRLayers(t *testing.T) {
+	var result bytes.Buffer
+	layers := []Layer{{Canvas: testCanvas()}, {Name: "depth", Canvas: testCanvas()}}
+	if err := WriteEXRLayers(&result, layers); err != nil {
+		t.Fatalf("WriteEXRLayers returned an error: %s", err)
+	}
+
+	channels, _ := readEXRHeader(t, result.Bytes())
+	expected := []string{"B", "G", "R", "depth.B", "depth.G", "depth.R"}
+	if len(channels) != len(expected) {
+		t.Fatalf("WriteEXRLayers expected channels %v, got %v", expected, channels)
+	}
+	for i := range expected {
+		if channels[i] != expected[i] {
+			t.Errorf("WriteEXRLayers expected channels %v, got %v", expected, channels)
+		}
+	}
+
+	// Layers of different sizes can't be combined
+	layers = append(layers, Layer{Name: "small", Canvas: testCanvas()[:1]})
+	if err := WriteEXRLayers(&result, layers); err == nil {
+		t.Errorf("WriteEXRLayers expected an error for layers of different sizes")
+	}
+	if err := WriteEXRLayers(&result, nil); err == nil {
+		t.Errorf("WriteEXRLayers expected an error without layers")
+	}
+}
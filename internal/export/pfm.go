@@ -0,0 +1,30 @@
+package export
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+)
+
+// WritePFM writes the canvas as a PFM image, every channel is a 32 bit float.
+// The colors are not clamped, so values above 1 are kept for later processing.
+// The negative scale in the header marks the data as little endian, the rows go from the bottom to the top.
+func WritePFM(w io.Writer, c canvas.Canvas) error {
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "PF\n%d %d\n-1.0\n", len(c), len(c[0]))
+
+	var pixel [12]byte
+	for y := len(c[0]) - 1; y >= 0; y-- {
+		for x := 0; x < len(c); x++ {
+			binary.LittleEndian.PutUint32(pixel[0:], math.Float32bits(float32(c[x][y].R)))
+			binary.LittleEndian.PutUint32(pixel[4:], math.Float32bits(float32(c[x][y].G)))
+			binary.LittleEndian.PutUint32(pixel[8:], math.Float32bits(float32(c[x][y].B)))
+			buf.Write(pixel[:])
+		}
+	}
+	return buf.Flush()
+}
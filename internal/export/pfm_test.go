@@ -0,0 +1,40 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestWritePFM(t *testing.T) {
+	var result bytes.Buffer
+	if err := WritePFM(&result, testCanvas()); err != nil {
+		t.Fatalf("WritePFM returned an error: %s", err)
+	}
+
+	header := "PF\n2 3\n-1.0\n"
+	data := result.Bytes()
+	if !bytes.HasPrefix(data, []byte(header)) {
+		t.Fatalf("WritePFM expected header %q, got %q", header, data[:len(header)])
+	}
+	data = data[len(header):]
+	if len(data) != 2*3*3*4 {
+		t.Fatalf("WritePFM expected %d bytes of data, got %d", 2*3*3*4, len(data))
+	}
+
+	channel := func(i int) float32 {
+		return math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	// the first pixel is the bottom left, the values are not clamped.
+	expected := []float32{0, 0, 0, 0.25, 1, 2}
+	for i, e := range expected {
+		if channel(i) != e {
+			t.Errorf("WritePFM channel %d expected %f, got %f", i, e, channel(i))
+		}
+	}
+	// the last row is the top of the image.
+	if channel(12) != 1.5 {
+		t.Errorf("WritePFM expected the top left red channel 1.5, got %f", channel(12))
+	}
+}
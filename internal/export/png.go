@@ -0,0 +1,26 @@
+package export
+
+import (
+	"image"
+	imagecolor "image/color"
+	"image/png"
+	"io"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+)
+
+// WritePNG writes the canvas as an 8 bit PNG image.
+func WritePNG(w io.Writer, c canvas.Canvas) error {
+	img := image.NewNRGBA(image.Rect(0, 0, len(c), len(c[0])))
+	for x := 0; x < len(c); x++ {
+		for y := 0; y < len(c[x]); y++ {
+			img.SetNRGBA(x, y, imagecolor.NRGBA{
+				R: uint8(rgbScale(c[x][y].R)),
+				G: uint8(rgbScale(c[x][y].G)),
+				B: uint8(rgbScale(c[x][y].B)),
+				A: 255,
+			})
+		}
+	}
+	return png.Encode(w, img)
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestWritePNG(t *testing.T) {
+	var result bytes.Buffer
+	if err := WritePNG(&result, testCanvas()); err != nil {
+		t.Fatalf("WritePNG returned an error: %s", err)
+	}
+
+	img, err := png.Decode(&result)
+	if err != nil {
+		t.Fatalf("WritePNG did not produce a valid png: %s", err)
+	}
+	if bounds := img.Bounds(); bounds.Dx() != 2 || bounds.Dy() != 3 {
+		t.Errorf("WritePNG expected a 2x3 image, got %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	var tests = []struct {
+		x, y    int
+		r, g, b uint32
+	}{
+		{x: 0, y: 0, r: 255, g: 0, b: 0},
+		{x: 1, y: 0, r: 0, g: 127, b: 0},
+		{x: 0, y: 1, r: 0, g: 0, b: 255},
+		{x: 1, y: 2, r: 63, g: 255, b: 255},
+	}
+	for _, test := range tests {
+		r, g, b, _ := img.At(test.x, test.y).RGBA()
+		if r>>8 != test.r || g>>8 != test.g || b>>8 != test.b {
+			t.Errorf("WritePNG pixel (%d, %d) expected (%d, %d, %d), got (%d, %d, %d)", test.x, test.y, test.r, test.g, test.b, r>>8, g>>8, b>>8)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+)
+
+// WriteP6 writes the canvas as a binary P6 PPM image, every channel is a single byte.
+func WriteP6(w io.Writer, c canvas.Canvas) error {
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "P6\n%d %d\n%d\n", len(c), len(c[0]), PpmMax)
+
+	for y := 0; y < len(c[0]); y++ {
+		for x := 0; x < len(c); x++ {
+			buf.WriteByte(byte(rgbScale(c[x][y].R)))
+			buf.WriteByte(byte(rgbScale(c[x][y].G)))
+			buf.WriteByte(byte(rgbScale(c[x][y].B)))
+		}
+	}
+	return buf.Flush()
+}
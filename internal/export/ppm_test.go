@@ -0,0 +1,37 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+// a canvas with two columns and three rows, the colors are out of range on purpose.
+func testCanvas() canvas.Canvas {
+	return canvas.Canvas{
+		[]color.Color{
+			color.New(1.5, 0, 0), color.New(-0.5, 0, 1), color.New(0, 0, 0),
+		},
+		[]color.Color{
+			color.New(0, 0.5, 0), color.New(0, 0, 0), color.New(0.25, 1, 2),
+		},
+	}
+}
+
+func TestWriteP6(t *testing.T) {
+	var result bytes.Buffer
+	if err := WriteP6(&result, testCanvas()); err != nil {
+		t.Fatalf("WriteP6 returned an error: %s", err)
+	}
+
+	expected := append([]byte("P6\n2 3\n255\n"),
+		255, 0, 0, 0, 127, 0,
+		0, 0, 255, 0, 0, 0,
+		0, 0, 0, 63, 255, 255,
+	)
+	if !bytes.Equal(result.Bytes(), expected) {
+		t.Errorf("WriteP6 expected\n%v\ngot\n%v", expected, result.Bytes())
+	}
+}
@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+)
+
+// Writer encodes a canvas into an image format.
+type Writer func(w io.Writer, c canvas.Canvas) error
+
+// LayerWriter encodes several images into a single file.
+type LayerWriter func(w io.Writer, layers []Layer) error
+
+var (
+	mu      sync.RWMutex
+	writers = map[string]Writer{
+		".png":    WritePNG,
+		".ppm":    WriteP6,
+		".p3.ppm": WriteP3, // the plain text PPM, for reading the values or diffing renders.
+		".pfm":    WritePFM,
+		".exr":    WriteEXR,
+	}
+	// formats that can hold several layers in one file.
+	layered = map[string]LayerWriter{
+		".exr": WriteEXRLayers,
+	}
+	// formats that keep the linear colors of the render, they are saved without post-processing.
+	hdr = map[string]bool{
+		".pfm": true,
+		".exr": true,
+	}
+)
+
+// Register adds a writer for the files with the given extension, e.g. ".ppm".
+// Extensions can have several parts, e.g. ".p3.ppm". Registering an extension again replaces its writer,
+// the format is no longer high dynamic range or layered.
+func Register(ext string, w Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	ext = strings.ToLower(ext)
+	writers[ext] = w
+	delete(hdr, ext)
+	delete(layered, ext)
+}
+
+// RegisterHDR adds a writer for a high dynamic range format, like Register.
+func RegisterHDR(ext string, w Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	ext = strings.ToLower(ext)
+	writers[ext] = w
+	hdr[ext] = true
+	delete(layered, ext)
+}
+
+// Extension returns the extension of the path that picks its format. It's the longest registered extension
+// the path ends with, so "render.p3.ppm" is ".p3.ppm", otherwise the last extension of the path.
+func Extension(path string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return extension(path)
+}
+
+func extension(path string) string {
+	lower := strings.ToLower(path)
+	ext := ""
+	for registered := range writers {
+		if strings.HasSuffix(lower, registered) && len(registered) > len(ext) {
+			ext = registered
+		}
+	}
+	if ext == "" {
+		return filepath.Ext(path)
+	}
+	// keeps the case of the path.
+	return path[len(path)-len(ext):]
+}
+
+// HDR reports whether the format of the path stores high dynamic range colors.
+// These images should be saved as rendered, tone mapping is left to the viewer.
+func HDR(path string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return hdr[strings.ToLower(extension(path))]
+}
+
+// Extensions returns the registered extensions in alphabetical order.
+func Extensions() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return sortedKeys(writers)
+}
+
+// WriterFor returns the writer that handles the extension of the path.
+func WriterFor(path string) (Writer, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	ext := strings.ToLower(extension(path))
+	if w, ok := writers[ext]; ok {
+		return w, nil
+	}
+	return nil, fmt.Errorf("unsupported image format: %q, use one of %s", ext, strings.Join(sortedKeys(writers), ", "))
+}
+
+func sortedKeys(m map[string]Writer) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Layered reports whether the format of the path can hold several layers in one file.
+func Layered(path string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := layered[strings.ToLower(extension(path))]
+	return ok
+}
+
+// Save writes the canvas to path, in the format matching the extension of the path.
+func Save(path string, c canvas.Canvas) error {
+	w, err := WriterFor(path)
+	if err != nil {
+		return err
+	}
+	return create(path, func(out io.Writer) error { return w(out, c) })
+}
+
+// SaveLayers writes the layers into a single file, the format of the path has to support layers.
+func SaveLayers(path string, layers []Layer) error {
+	mu.RLock()
+	ext := strings.ToLower(extension(path))
+	w, ok := layered[ext]
+	mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("image format %q can't hold layers", ext)
+	}
+	return create(path, func(out io.Writer) error { return w(out, layers) })
+}
+
+// creates the file at path and fills it with write.
+func create(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	buf := bufio.NewWriter(f)
+	if err := write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := buf.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,165 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+)
+
+func TestWriterFor(t *testing.T) {
+	var tests = []struct {
+		path   string
+		header string
+		err    bool
+	}{
+		{path: "renders/render.png", header: "\x89PNG"},
+		{path: "renders/render.PNG", header: "\x89PNG"},
+		{path: "render.ppm", header: "P6"},
+		{path: "render.p3.ppm", header: "P3"},
+		{path: "render.P3.PPM", header: "P3"},
+		{path: "render.pfm", header: "PF"},
+		{path: "render.exr", header: "\x76\x2f\x31\x01"},
+		{path: "render.jpg", err: true},
+		{path: "render", err: true},
+	}
+
+	for _, test := range tests {
+		w, err := WriterFor(test.path)
+		if test.err {
+			if err == nil {
+				t.Errorf("WriterFor(%s) expected an error", test.path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("WriterFor(%s) returned an error: %s", test.path, err)
+			continue
+		}
+
+		var result bytes.Buffer
+		w(&result, testCanvas())
+		if !bytes.HasPrefix(result.Bytes(), []byte(test.header)) {
+			t.Errorf("WriterFor(%s) expected a file starting with %q", test.path, test.header)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(".TXT", func(w io.Writer, c canvas.Canvas) error {
+		_, err := w.Write([]byte("text"))
+		return err
+	})
+	defer func() {
+		mu.Lock()
+		delete(writers, ".txt")
+		mu.Unlock()
+	}()
+
+	path := filepath.Join(t.TempDir(), "render.txt")
+	if err := Save(path, testCanvas()); err != nil {
+		t.Fatalf("Save returned an error: %s", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "text" {
+		t.Errorf("Save expected the registered writer to be used, got %q", data)
+	}
+}
+
+func TestExtension(t *testing.T) {
+	var tests = []struct {
+		path     string
+		expected string
+	}{
+		{path: "renders/render.png", expected: ".png"},
+		{path: "render.p3.ppm", expected: ".p3.ppm"},
+		{path: "render.P3.ppm", expected: ".P3.ppm"},
+		{path: "render.v2.ppm", expected: ".ppm"},
+		{path: "render.jpg", expected: ".jpg"},
+		{path: "render", expected: ""},
+	}
+
+	for _, test := range tests {
+		if result := Extension(test.path); result != test.expected {
+			t.Errorf("Extension(%s) expected %q, got %q", test.path, test.expected, result)
+		}
+	}
+}
+
+func TestRegisterReplacesTheFormat(t *testing.T) {
+	// an exr writer registered again is neither high dynamic range nor layered any more.
+	Register(".exr", WritePNG)
+	defer func() {
+		mu.Lock()
+		writers[".exr"] = WriteEXR
+		hdr[".exr"] = true
+		layered[".exr"] = WriteEXRLayers
+		mu.Unlock()
+	}()
+
+	if HDR("render.exr") {
+		t.Errorf("Register expected .exr to be a low dynamic range format")
+	}
+	if Layered("render.exr") {
+		t.Errorf("Register expected .exr to hold no layers")
+	}
+}
+
+func TestHDR(t *testing.T) {
+	var tests = []struct {
+		path     string
+		expected bool
+	}{
+		{path: "render.pfm", expected: true},
+		{path: "render.PFM", expected: true},
+		{path: "render.exr", expected: true},
+		{path: "render.png", expected: false},
+		{path: "render.ppm", expected: false},
+		{path: "render", expected: false},
+	}
+
+	for _, test := range tests {
+		if result := HDR(test.path); result != test.expected {
+			t.Errorf("HDR(%s) expected %t, got %t", test.path, test.expected, result)
+		}
+	}
+
+	RegisterHDR(".hdr", WritePFM)
+	defer func() {
+		mu.Lock()
+		delete(writers, ".hdr")
+		delete(hdr, ".hdr")
+		mu.Unlock()
+	}()
+	if !HDR("render.hdr") {
+		t.Errorf("RegisterHDR expected .hdr to be a high dynamic range format")
+	}
+}
+
+func TestSaveLayers(t *testing.T) {
+	layers := []Layer{{Canvas: testCanvas()}, {Name: "depth", Canvas: testCanvas()}}
+
+	path := filepath.Join(t.TempDir(), "render.exr")
+	if !Layered(path) {
+		t.Errorf("Layered(%s) expected true", path)
+	}
+	if err := SaveLayers(path, layers); err != nil {
+		t.Fatalf("SaveLayers returned an error: %s", err)
+	}
+	data, _ := os.ReadFile(path)
+	if channels, _ := readEXRHeader(t, data); len(channels) != 6 {
+		t.Errorf("SaveLayers expected 6 channels, got %v", channels)
+	}
+
+	// Formats without layers are rejected
+	path = filepath.Join(t.TempDir(), "render.png")
+	if Layered(path) {
+		t.Errorf("Layered(%s) expected false", path)
+	}
+	if err := SaveLayers(path, layers); err == nil {
+		t.Errorf("SaveLayers(%s) expected an error", path)
+	}
+}
@@ -0,0 +1,99 @@
+package light
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// DefaultSamples is the number of samples along each side of an area light.
+const DefaultSamples = 4
+
+// AreaShape is the outline of an area light.
+type AreaShape int
+
+const (
+	Rect AreaShape = iota
+	Disk
+)
+
+func (s AreaShape) String() string {
+	switch s {
+	case Rect:
+		return "rect"
+	case Disk:
+		return "disk"
+	default:
+		return fmt.Sprintf("AreaShape(%d)", int(s))
+	}
+}
+
+// AreaLight is a flat light source with a surface, it casts soft shadows.
+// Points that see only a part of the light are in the penumbra.
+// The surface is divided into a samples×samples grid and each cell is sampled at a random point,
+// so the penumbra has noise instead of bands.
+type AreaLight struct {
+	common
+	shape     AreaShape
+	center    tuple.Tuple
+	u, v      tuple.Tuple // the edges of a rectangle, the radii of a disk along two perpendicular axes.
+	intensity color.Color
+	samples   int
+}
+
+// NewRectLight creates a rectangular light centered at center, with the edges u and v.
+func NewRectLight(center, u, v tuple.Tuple, intensity color.Color, samples int) *AreaLight {
+	return &AreaLight{shape: Rect, center: center, u: u, v: v, intensity: intensity, samples: sampleCount(samples)}
+}
+
+// NewDiskLight creates a round light centered at center, facing the direction of the normal.
+func NewDiskLight(center, normal tuple.Tuple, radius float64, intensity color.Color, samples int) *AreaLight {
+	u, v := tuple.Basis(normal.Normalize())
+	return &AreaLight{shape: Disk, center: center, u: u.Scalar(radius), v: v.Scalar(radius), intensity: intensity, samples: sampleCount(samples)}
+}
+
+func sampleCount(samples int) int {
+	if samples < 1 {
+		return DefaultSamples
+	}
+	return samples
+}
+
+func (l *AreaLight) String() string {
+	return fmt.Sprintf("AreaLight(shape: %s, center: %f, u: %f, v: %f, intensity: %f, samples: %d)", l.shape, l.center, l.u, l.v, l.intensity, l.samples)
+}
+
+func (l *AreaLight) Intensity() color.Color {
+	return l.intensity
+}
+
+// Ambient is attenuated by the distance of the center of the light.
+func (l *AreaLight) Ambient(point tuple.Tuple) color.Color {
+	return l.ambient(l.center, point, l.intensity)
+}
+
+func (l *AreaLight) Samples(point tuple.Tuple) []Sample {
+	n := l.samples
+	result := make([]Sample, 0, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			s := (float64(i) + rand.Float64()) / float64(n)
+			t := (float64(j) + rand.Float64()) / float64(n)
+			result = append(result, l.sample(l.pointAt(s, t), point, l.intensity))
+		}
+	}
+	return result
+}
+
+// maps s and t from [0, 1] to a point on the surface of the light.
+func (l *AreaLight) pointAt(s, t float64) tuple.Tuple {
+	if l.shape == Disk {
+		s, t = utils.ConcentricDisk(s, t)
+	} else {
+		s, t = s-0.5, t-0.5
+	}
+	return tuple.Add(l.center, tuple.Add(l.u.Scalar(s), l.v.Scalar(t)))
+}
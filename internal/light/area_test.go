@@ -0,0 +1,79 @@
+package light
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// the position of the light the sample points to.
+func samplePosition(point tuple.Tuple, s Sample) tuple.Tuple {
+	return tuple.Add(point, s.Direction.Scalar(s.Distance))
+}
+
+func TestRectLightSamples(t *testing.T) {
+	l := NewRectLight(tuple.NewPoint(0, 5, 0), tuple.NewVector(2, 0, 0), tuple.NewVector(0, 0, 4), color.White(), 3)
+	point := tuple.NewPoint(1, 0, 1)
+	samples := l.Samples(point)
+
+	if len(samples) != 9 {
+		t.Errorf("expected 9 samples, got %d", len(samples))
+	}
+	for _, s := range samples {
+		p := samplePosition(point, s)
+		if !utils.FloatEquals(p.Y, 5) || math.Abs(p.X) > 1 || math.Abs(p.Z) > 2 {
+			t.Errorf("sample %s is outside of the light", p)
+		}
+		if !utils.FloatEquals(s.Direction.Magnitude(), 1) {
+			t.Errorf("sample direction %s is not normalized", s.Direction)
+		}
+	}
+}
+
+func TestDiskLightSamples(t *testing.T) {
+	l := NewDiskLight(tuple.NewPoint(0, 0, 3), tuple.NewVector(0, 0, -1), 2, color.White(), 0)
+	point := tuple.NewPoint(0, 0, 0)
+	samples := l.Samples(point)
+
+	if len(samples) != DefaultSamples*DefaultSamples {
+		t.Errorf("expected %d samples, got %d", DefaultSamples*DefaultSamples, len(samples))
+	}
+	for _, s := range samples {
+		p := samplePosition(point, s)
+		if !utils.FloatEquals(p.Z, 3) || math.Hypot(p.X, p.Y) > 2+utils.EPSILON {
+			t.Errorf("sample %s is outside of the light", p)
+		}
+	}
+}
+
+func TestLightingPartialShadow(t *testing.T) {
+	// Half of the light is hidden, the samples that are seen light the point.
+	shape := shapes.NewSphere()
+	mat := shape.Material()
+	l := NewRectLight(tuple.NewPoint(0, 0, -10), tuple.NewVector(4, 0, 0), tuple.NewVector(0, 4, 0), color.New(1, 1, 1), 2)
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+
+	var tested int
+	var expected color.Color
+	visible := func(s Sample) bool {
+		tested++
+		if s.Direction.X < 0 {
+			return false
+		}
+		expected = color.Add(expected, direct(mat, color.White(), s, eyeV, normalV))
+		return true
+	}
+	result := Lighting(shape, l, tuple.NewPoint(0, 0, 0), eyeV, normalV, visible)
+	expected = color.Add(expected.Scalar(0.25), color.New(0.1, 0.1, 0.1))
+	if tested != 4 || !result.Equal(expected) {
+		t.Errorf("Lighting with half shadow expected %s from %d samples, got %s from %d", expected, 4, result, tested)
+	}
+	if result.R >= 1.5 || result.R <= 0.5 {
+		t.Errorf("Lighting with half shadow expected about half of the light, got %s", result)
+	}
+}
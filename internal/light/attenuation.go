@@ -0,0 +1,71 @@
+package light
+
+import (
+	"fmt"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Attenuation describes how the intensity of a light falls off with distance.
+// The intensity is divided by Constant + Linear*d + Quadratic*d², the zero value keeps it constant.
+type Attenuation struct {
+	Constant, Linear, Quadratic float64
+}
+
+// InverseSquare is the physically correct falloff of a light.
+var InverseSquare = Attenuation{Quadratic: 1}
+
+func (a Attenuation) String() string {
+	return fmt.Sprintf("Attenuation(constant: %f, linear: %f, quadratic: %f)", a.Constant, a.Linear, a.Quadratic)
+}
+
+// Factor returns the fraction of the intensity that reaches the given distance.
+func (a Attenuation) Factor(distance float64) float64 {
+	denominator := a.Constant + a.Linear*distance + a.Quadratic*distance*distance
+	if denominator <= 0 {
+		return 1
+	}
+	return 1 / denominator
+}
+
+// options shared by all lights.
+type common struct {
+	attenuation Attenuation
+	noShadows   bool
+}
+
+func (c *common) Attenuation() Attenuation {
+	return c.attenuation
+}
+
+func (c *common) SetAttenuation(a Attenuation) {
+	c.attenuation = a
+}
+
+// CastsShadows reports whether objects can block the light, true by default.
+func (c *common) CastsShadows() bool {
+	return !c.noShadows
+}
+
+// SetCastsShadows turns the shadows of the light on or off.
+// Fill lights without shadows brighten the scene without cluttering the image.
+func (c *common) SetCastsShadows(casts bool) {
+	c.noShadows = !casts
+}
+
+// the ambient light of a light at position, attenuated by the distance of the point.
+func (c *common) ambient(position, point tuple.Tuple, intensity color.Color) color.Color {
+	return intensity.Scalar(c.attenuation.Factor(tuple.Subtract(position, point).Magnitude()))
+}
+
+// the sample of a light at position, seen from point, attenuated by the distance.
+func (c *common) sample(position, point tuple.Tuple, intensity color.Color) Sample {
+	v := tuple.Subtract(position, point)
+	distance := v.Magnitude()
+	return Sample{
+		Direction: v.Normalize(),
+		Distance:  distance,
+		Intensity: intensity.Scalar(c.attenuation.Factor(distance)),
+	}
+}
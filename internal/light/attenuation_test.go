@@ -0,0 +1,112 @@
+package light
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestAttenuationFactor(t *testing.T) {
+	var tests = []struct {
+		attenuation Attenuation
+		distance    float64
+		expected    float64
+	}{
+		{attenuation: Attenuation{}, distance: 10, expected: 1},
+		{attenuation: InverseSquare, distance: 2, expected: 0.25},
+		{attenuation: InverseSquare, distance: 10, expected: 0.01},
+		{attenuation: Attenuation{Constant: 1, Linear: 0.5}, distance: 2, expected: 0.5},
+		{attenuation: Attenuation{Constant: 1, Linear: 0.1, Quadratic: 0.01}, distance: 10, expected: 1.0 / 3.0},
+	}
+
+	for _, test := range tests {
+		if result := test.attenuation.Factor(test.distance); !utils.FloatEquals(result, test.expected) {
+			t.Errorf("%s.Factor(%f) expected %f, got %f", test.attenuation, test.distance, test.expected, result)
+		}
+	}
+}
+
+func TestAttenuatedSamples(t *testing.T) {
+	var tests = []struct {
+		light Light
+	}{
+		{light: NewLight(tuple.NewPoint(0, 2, 0), color.White())},
+		{light: NewSpotLight(tuple.NewPoint(0, 2, 0), tuple.NewVector(0, -1, 0), 0.5, 0.6, color.White())},
+		{light: NewRectLight(tuple.NewPoint(0, 2, 0), tuple.NewVector(0.001, 0, 0), tuple.NewVector(0, 0, 0.001), color.White(), 1)},
+	}
+
+	for _, test := range tests {
+		test.light.SetAttenuation(InverseSquare)
+		sample := test.light.Samples(tuple.NewPoint(0, 0, 0))[0]
+		// the tiny area light is sampled at a random point, its distance varies a little.
+		if math.Abs(sample.Intensity.R-0.25) > 0.0001 {
+			t.Errorf("%s attenuated intensity expected 0.25, got %f", test.light, sample.Intensity.R)
+		}
+	}
+
+	// Directional lights are at infinity and keep their intensity.
+	l := NewDirectionalLight(tuple.NewVector(0, -1, 0), color.White())
+	l.SetAttenuation(InverseSquare)
+	if sample := l.Samples(tuple.NewPoint(0, 0, 0))[0]; !sample.Intensity.Equal(color.White()) {
+		t.Errorf("directional light expected no attenuation, got %s", sample.Intensity)
+	}
+}
+
+func TestCastsShadows(t *testing.T) {
+	l := NewLight(tuple.NewPoint(0, 2, 0), color.White())
+	if !l.CastsShadows() {
+		t.Errorf("lights should cast shadows by default")
+	}
+	l.SetCastsShadows(false)
+	if l.CastsShadows() {
+		t.Errorf("light should not cast shadows")
+	}
+}
+
+func TestLightingReceivesShadow(t *testing.T) {
+	// Shapes that don't receive shadows are lit as if they were not in shadow.
+	shape := shapes.NewSphere()
+	shape.Material().ReceivesShadow = false
+	l := NewLight(tuple.NewPoint(0, 0, -10), color.New(1, 1, 1))
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+
+	result := Lighting(shape, l, tuple.NewPoint(0, 0, 0), eyeV, normalV, hidden)
+	expected := color.New(1.9, 1.9, 1.9)
+	if !result.Equal(expected) {
+		t.Errorf("Lighting without receiving shadows expected %s, got %s", expected, result)
+	}
+}
+
+func TestAttenuatedAmbient(t *testing.T) {
+	// A bright light far away adds as much ambient light as a dim one close by
+	var tests = []struct {
+		light Light
+	}{
+		{light: NewLight(tuple.NewPoint(0, 0, -10), color.New(100, 100, 100))},
+		{light: NewSpotLight(tuple.NewPoint(0, 0, -10), tuple.NewVector(0, 0, 1), 0.5, 0.6, color.New(100, 100, 100))},
+		{light: NewRectLight(tuple.NewPoint(0, 0, -10), tuple.NewVector(1, 0, 0), tuple.NewVector(0, 1, 0), color.New(100, 100, 100), 2)},
+	}
+	shape := shapes.NewSphere()
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+
+	for _, test := range tests {
+		test.light.SetAttenuation(InverseSquare)
+		result := Lighting(shape, test.light, tuple.NewPoint(0, 0, 0), eyeV, normalV, hidden)
+		if expected := color.New(0.1, 0.1, 0.1); !result.Equal(expected) {
+			t.Errorf("%s attenuated ambient expected %s, got %s", test.light, expected, result)
+		}
+	}
+
+	// Directional lights keep their intensity
+	l := NewDirectionalLight(tuple.NewVector(0, 0, 1), color.White())
+	l.SetAttenuation(InverseSquare)
+	if result := Lighting(shape, l, tuple.NewPoint(0, 0, 0), eyeV, normalV, hidden); !result.Equal(color.New(0.1, 0.1, 0.1)) {
+		t.Errorf("directional light expected the full ambient, got %s", result)
+	}
+}
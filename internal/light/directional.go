@@ -0,0 +1,42 @@
+package light
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// DirectionalLight is a light at infinity, like the sun. Its rays are parallel and
+// it lights every point of the scene from the same direction with the same intensity,
+// the attenuation does not apply to it.
+type DirectionalLight struct {
+	common
+	direction tuple.Tuple // the direction the light travels in.
+	intensity color.Color
+}
+
+func NewDirectionalLight(direction tuple.Tuple, intensity color.Color) *DirectionalLight {
+	return &DirectionalLight{direction: direction.Normalize(), intensity: intensity}
+}
+
+func (l *DirectionalLight) String() string {
+	return fmt.Sprintf("DirectionalLight(direction: %f, intensity: %f)", l.direction, l.intensity)
+}
+
+func (l *DirectionalLight) Direction() tuple.Tuple {
+	return l.direction
+}
+
+func (l *DirectionalLight) Intensity() color.Color {
+	return l.intensity
+}
+
+func (l *DirectionalLight) Ambient(point tuple.Tuple) color.Color {
+	return l.intensity
+}
+
+func (l *DirectionalLight) Samples(point tuple.Tuple) []Sample {
+	return []Sample{{Direction: l.direction.Negate(), Distance: math.Inf(1), Intensity: l.intensity}}
+}
@@ -0,0 +1,41 @@
+package light
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+func TestDirectionalLightSamples(t *testing.T) {
+	l := NewDirectionalLight(tuple.NewVector(0, -2, 0), color.White())
+
+	for _, point := range []tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(100, -50, 3)} {
+		samples := l.Samples(point)
+		if len(samples) != 1 {
+			t.Errorf("expected 1 sample, got %d", len(samples))
+		}
+		if !samples[0].Direction.Equal(tuple.NewVector(0, 1, 0)) {
+			t.Errorf("expected the sample to point against the light's direction, got %s", samples[0].Direction)
+		}
+		if !math.IsInf(samples[0].Distance, 1) {
+			t.Errorf("expected the light to be at infinity, got %f", samples[0].Distance)
+		}
+	}
+}
+
+func TestLightingDirectional(t *testing.T) {
+	// Lighting with the eye between the light and the surface
+	shape := shapes.NewSphere()
+	l := NewDirectionalLight(tuple.NewVector(0, 0, 1), color.New(1, 1, 1))
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+
+	result := Lighting(shape, l, tuple.NewPoint(0, 0, 0), eyeV, normalV, Unblocked)
+	expected := color.New(1.9, 1.9, 1.9)
+	if !result.Equal(expected) {
+		t.Errorf("Lighting with a directional light expected %s, got %s", expected, result)
+	}
+}
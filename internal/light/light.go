@@ -3,32 +3,72 @@ package light
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/materials"
 	"github.com/kaizencodes/glimpse/internal/shapes"
 	"github.com/kaizencodes/glimpse/internal/tuple"
 )
 
-type Light struct {
+// Light is a source of illumination in the scene.
+type Light interface {
+	Intensity() color.Color
+	// Ambient returns the light added to the ambient term of the given point, it falls off like the light itself.
+	Ambient(point tuple.Tuple) color.Color
+	// CastsShadows reports whether objects can block the light.
+	CastsShadows() bool
+	SetCastsShadows(casts bool)
+	// SetAttenuation sets how the intensity falls off with the distance from the light.
+	SetAttenuation(a Attenuation)
+	// Samples returns the points of the light that illuminate the given point.
+	// The shading is averaged over the samples, the hidden ones add no light.
+	Samples(point tuple.Tuple) []Sample
+}
+
+// Visibility reports whether a sample of a light reaches the shaded point, objects in between can hide it.
+type Visibility func(s Sample) bool
+
+// Unblocked is the visibility of a point that sees every sample of the light.
+func Unblocked(s Sample) bool {
+	return true
+}
+
+// Sample is a point of a light as seen from the point being shaded.
+type Sample struct {
+	Direction tuple.Tuple // normalized vector pointing from the shaded point towards the light.
+	Distance  float64     // distance between the shaded point and the light, infinite for lights at infinity.
+	Intensity color.Color // the light arriving at the shaded point from this sample.
+}
+
+// PointLight is an infinitely small light source, its shadows have hard edges.
+type PointLight struct {
+	common
 	position  tuple.Tuple
 	intensity color.Color
 }
 
-func (l Light) String() string {
+func (l *PointLight) String() string {
 	return fmt.Sprintf("Light(position: %f, intensity: %f)", l.position, l.intensity)
 }
 
-func (l Light) Position() tuple.Tuple {
+func (l *PointLight) Position() tuple.Tuple {
 	return l.position
 }
 
-func (l Light) Intensity() color.Color {
+func (l *PointLight) Intensity() color.Color {
 	return l.intensity
 }
 
-func NewLight(position tuple.Tuple, intensity color.Color) Light {
-	return Light{position, intensity}
+func (l *PointLight) Ambient(point tuple.Tuple) color.Color {
+	return l.ambient(l.position, point, l.intensity)
+}
+
+func (l *PointLight) Samples(point tuple.Tuple) []Sample {
+	return []Sample{l.sample(l.position, point, l.intensity)}
+}
+
+func NewLight(position tuple.Tuple, intensity color.Color) *PointLight {
+	return &PointLight{position: position, intensity: intensity}
 }
 
 // The Phong reflection model is a method for approximating the illumination of points on a surface.
@@ -41,46 +81,51 @@ func NewLight(position tuple.Tuple, intensity color.Color) Light {
 //
 // It depends only on the angle between the reflection vector and the eye vector and is controlled by a parameter that we’ll call shininess.
 // The higher the shininess, the smaller and tighter the specular highlight.
-func Lighting(shape shapes.Shape, light Light, point, eyeV, normalV tuple.Tuple, inShadow bool) color.Color {
+//
+// The diffuse and specular contributions come from the BSDF of the material, which is Phong by default.
+//
+// Visible reports which samples of the light the point sees, the hidden ones are in shadow.
+// For lights with multiple samples the diffuse and specular contributions are averaged over the samples,
+// so the penumbra of area lights comes from the same samples as the shading.
+func Lighting(shape shapes.Shape, light Light, point, eyeV, normalV tuple.Tuple, visible Visibility) color.Color {
 	mat := shape.Material()
 	coloring := shapes.ColorAt(point, shape)
-	// combine the surface color with the light's color/intensity
-	effectiveColor := color.HadamardProduct(coloring, light.intensity)
-	// find the direction to the light source
-	lightV := tuple.Subtract(light.position, point).Normalize()
+	// combine the surface color with the light's color/intensity, attenuated by the distance.
+	effectiveColor := color.HadamardProduct(coloring, light.Ambient(point))
 	// compute the ambient contribution
 	ambient := effectiveColor.Scalar(mat.Ambient)
-	if inShadow {
-		// in shadow, we only need to return the ambient contribution.
-		return ambient
+
+	// Add the three contributions together to get the final shading.
+	return color.Add(ambient, directLighting(shape, coloring, light, point, eyeV, normalV, visible))
+}
+
+// DirectLighting is Lighting without the ambient contribution, the light arriving straight from the light source.
+// Renderers that compute the light bouncing between surfaces use it instead of the constant ambient term.
+func DirectLighting(shape shapes.Shape, light Light, point, eyeV, normalV tuple.Tuple, visible Visibility) color.Color {
+	return directLighting(shape, shapes.ColorAt(point, shape), light, point, eyeV, normalV, visible)
+}
+
+func directLighting(shape shapes.Shape, coloring color.Color, light Light, point, eyeV, normalV tuple.Tuple, visible Visibility) color.Color {
+	mat := shape.Material()
+	if !mat.ReceivesShadow {
+		visible = Unblocked
 	}
 
-	// lightDotNormal represents the cosine of the angle between the
-	// light vector and the normal vector. A negative number means the
-	// light is on the other side of the surface.
-	lightDotNormal := tuple.Dot(lightV, normalV)
-
-	var diffuse, specular color.Color
-	if lightDotNormal < 0 {
-		diffuse = color.Black()
-		specular = color.Black()
-	} else {
-		// compute the diffuse contribution
-		diffuse = effectiveColor.Scalar(mat.Diffuse * lightDotNormal)
-		// reflectDotEye represents the cosine of the angle between the
-		// reflection vector and the eye vector. A negative number means the
-		// light reflects away from the eye.
-		reflectV := tuple.Reflect(lightV.Negate(), normalV)
-		reflectDotEye := tuple.Dot(reflectV, eyeV)
-		if reflectDotEye <= 0 {
-			specular = color.Black()
-		} else {
-			// compute the specular contribution
-			factor := math.Pow(reflectDotEye, mat.Shininess)
-			specular = light.intensity.Scalar(mat.Specular * factor)
+	samples := light.Samples(point)
+	var sum color.Color
+	for i := 0; i < len(samples); i++ {
+		contribution := direct(mat, coloring, samples[i], eyeV, normalV)
+		// samples that add no light don't need to be tested for shadows.
+		if contribution == color.Black() || !visible(samples[i]) {
+			continue
 		}
+		sum = color.Add(sum, contribution)
 	}
 
-	// Add the three contributions together to get the final shading.
-	return color.Add(color.Add(ambient, diffuse), specular)
+	return sum.Scalar(1 / float64(len(samples)))
+}
+
+// The diffuse and specular contributions of the light arriving from a sample.
+func direct(mat *materials.Material, coloring color.Color, sample Sample, eyeV, normalV tuple.Tuple) color.Color {
+	return mat.BSDF().Evaluate(mat, coloring, sample.Direction, eyeV, normalV, sample.Intensity)
 }
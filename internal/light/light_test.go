@@ -10,12 +10,17 @@ import (
 	"github.com/kaizencodes/glimpse/internal/tuple"
 )
 
+// the visibility of a point in shadow.
+func hidden(s Sample) bool {
+	return false
+}
+
 func TestLighting(t *testing.T) {
 	var tests = []struct {
 		eyeV     tuple.Tuple
 		normalV  tuple.Tuple
 		light    Light
-		inShadow bool
+		visible  Visibility
 		expected color.Color
 	}{
 		{
@@ -23,7 +28,7 @@ func TestLighting(t *testing.T) {
 			eyeV:     tuple.NewVector(0, 0, -1),
 			normalV:  tuple.NewVector(0, 0, -1),
 			light:    NewLight(tuple.NewPoint(0, 0, -10), color.New(1, 1, 1)),
-			inShadow: false,
+			visible:  Unblocked,
 			expected: color.New(1.9, 1.9, 1.9),
 		},
 		{
@@ -31,7 +36,7 @@ func TestLighting(t *testing.T) {
 			eyeV:     tuple.NewVector(0, math.Sqrt(2)/2.0, -math.Sqrt(2)/2.0),
 			normalV:  tuple.NewVector(0, 0, -1),
 			light:    NewLight(tuple.NewPoint(0, 0, -10), color.New(1, 1, 1)),
-			inShadow: false,
+			visible:  Unblocked,
 			expected: color.New(1.0, 1.0, 1.0),
 		},
 		{
@@ -39,7 +44,7 @@ func TestLighting(t *testing.T) {
 			eyeV:     tuple.NewVector(0, 0, -1),
 			normalV:  tuple.NewVector(0, 0, -1),
 			light:    NewLight(tuple.NewPoint(0, 10, -10), color.New(1, 1, 1)),
-			inShadow: false,
+			visible:  Unblocked,
 			expected: color.New(0.7363961030678927, 0.7363961030678927, 0.7363961030678927),
 		},
 		{
@@ -47,7 +52,7 @@ func TestLighting(t *testing.T) {
 			eyeV:     tuple.NewVector(0, -math.Sqrt(2)/2.0, -math.Sqrt(2)/2.0),
 			normalV:  tuple.NewVector(0, 0, -1),
 			light:    NewLight(tuple.NewPoint(0, 10, -10), color.New(1, 1, 1)),
-			inShadow: false,
+			visible:  Unblocked,
 			expected: color.New(1.6363961030678928, 1.6363961030678928, 1.6363961030678928),
 		},
 		{
@@ -55,7 +60,7 @@ func TestLighting(t *testing.T) {
 			eyeV:     tuple.NewVector(0, 0, -1),
 			normalV:  tuple.NewVector(0, 0, -1),
 			light:    NewLight(tuple.NewPoint(0, 0, 10), color.New(1, 1, 1)),
-			inShadow: false,
+			visible:  Unblocked,
 			expected: color.New(0.1, 0.1, 0.1),
 		},
 		{
@@ -63,7 +68,7 @@ func TestLighting(t *testing.T) {
 			eyeV:     tuple.NewVector(0, 0, -1),
 			normalV:  tuple.NewVector(0, 0, -1),
 			light:    NewLight(tuple.NewPoint(0, 0, -10), color.New(1, 1, 1)),
-			inShadow: true,
+			visible:  hidden,
 			expected: color.New(0.1, 0.1, 0.1),
 		},
 	}
@@ -71,8 +76,8 @@ func TestLighting(t *testing.T) {
 	shape := shapes.NewSphere()
 	pos := tuple.NewPoint(0, 0, 0)
 	for _, test := range tests {
-		if got := Lighting(shape, test.light, pos, test.eyeV, test.normalV, test.inShadow); !got.Equal(test.expected) {
-			t.Errorf("Lighting:\n light: %s \neyeV: %s \nnormalV: %s\ninShadow: %t\ngot: \n%s. \nexpected: \n%s", test.light, test.eyeV, test.normalV, test.inShadow, got, test.expected)
+		if got := Lighting(shape, test.light, pos, test.eyeV, test.normalV, test.visible); !got.Equal(test.expected) {
+			t.Errorf("Lighting:\n light: %s \neyeV: %s \nnormalV: %s\ngot: \n%s. \nexpected: \n%s", test.light, test.eyeV, test.normalV, got, test.expected)
 		}
 	}
 
@@ -81,21 +86,37 @@ func TestLighting(t *testing.T) {
 	eyeV := tuple.NewVector(0, 0, -1)
 	normalV := tuple.NewVector(0, 0, -1)
 	light := NewLight(tuple.NewPoint(0, 0, -10), color.New(1, 1, 1))
-	inShadow := false
 	ambientMat := materials.NewMaterial(color.White(), 1, 0, 0, 0, 0, 0, 1)
 	ambientMat.SetPattern(materials.NewPattern(materials.Stripe, color.White(), color.Black()))
 	shape.SetMaterial(ambientMat)
 	pos1 := tuple.NewPoint(0.9, 0, 0)
 	pos2 := tuple.NewPoint(1.1, 0, 0)
 
-	color1 := Lighting(shape, light, pos1, eyeV, normalV, inShadow)
-	color2 := Lighting(shape, light, pos2, eyeV, normalV, inShadow)
+	color1 := Lighting(shape, light, pos1, eyeV, normalV, Unblocked)
+	color2 := Lighting(shape, light, pos2, eyeV, normalV, Unblocked)
 
 	if !color1.Equal(color.White()) {
-		t.Errorf("Lighting:\n light: %s \neyeV: %s \nnormalV: %s\ninShadow: %t\ngot: \n%s. \nexpected: \n%s", light, eyeV, normalV, inShadow, color1, color.White())
+		t.Errorf("Lighting:\n light: %s \neyeV: %s \nnormalV: %s\ngot: \n%s. \nexpected: \n%s", light, eyeV, normalV, color1, color.White())
 	}
 	if !color2.Equal(color.Black()) {
-		t.Errorf("Lighting:\n light: %s \neyeV: %s \nnormalV: %s\ninShadow: %t\ngot: \n%s. \nexpected: \n%s", light, eyeV, normalV, inShadow, color1, color.Black())
+		t.Errorf("Lighting:\n light: %s \neyeV: %s \nnormalV: %s\ngot: \n%s. \nexpected: \n%s", light, eyeV, normalV, color1, color.Black())
 	}
 
 }
+
+func TestDirectLighting(t *testing.T) {
+	// Direct lighting is the lighting without the ambient contribution
+	shape := shapes.NewSphere()
+	l := NewLight(tuple.NewPoint(0, 10, -10), color.New(1, 1, 1))
+	point := tuple.NewPoint(0, 0, 0)
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+	ambient := color.New(0.1, 0.1, 0.1)
+
+	for _, visible := range []Visibility{Unblocked, hidden} {
+		result := color.Add(ambient, DirectLighting(shape, l, point, eyeV, normalV, visible))
+		if expected := Lighting(shape, l, point, eyeV, normalV, visible); !result.Equal(expected) {
+			t.Errorf("DirectLighting plus ambient expected %s, got %s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package light
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// MeshLight turns the triangles of emissive models into a light, so they illuminate the scene and cast shadows.
+// The surface of the mesh is sampled at samples×samples random points, larger triangles get more of them.
+// Unlike the other lights the intensity depends on the emission of the triangles, their size and distance,
+// and the angle they are seen from.
+type MeshLight struct {
+	common
+	triangles []meshTriangle
+	areas     []float64 // the running sum of the triangle areas, used to pick the triangles.
+	members   map[shapes.Shape]bool
+	samples   int
+}
+
+// a triangle in scene space.
+type meshTriangle struct {
+	p1, e1, e2 tuple.Tuple
+	normal     tuple.Tuple
+	emission   color.Color
+}
+
+// NewMeshLight creates a light from the triangles, they emit the emission of their material.
+func NewMeshLight(triangles []*shapes.Triangle, samples int) *MeshLight {
+	l := &MeshLight{members: make(map[shapes.Shape]bool, len(triangles)), samples: sampleCount(samples)}
+	total := 0.0
+	for _, t := range triangles {
+		p1 := shapes.PointToScene(t.P1, t)
+		e1 := tuple.Subtract(shapes.PointToScene(t.P2, t), p1)
+		e2 := tuple.Subtract(shapes.PointToScene(t.P3, t), p1)
+		cross := tuple.Cross(e1, e2)
+		area := cross.Magnitude() / 2
+		// degenerate triangles can't be sampled.
+		if area == 0 {
+			continue
+		}
+
+		total += area
+		l.triangles = append(l.triangles, meshTriangle{p1: p1, e1: e1, e2: e2, normal: cross.Normalize(), emission: t.Material().Emission})
+		l.areas = append(l.areas, total)
+		l.members[t] = true
+	}
+	return l
+}
+
+func (l *MeshLight) String() string {
+	return fmt.Sprintf("MeshLight(triangles: %d, area: %f, samples: %d)", len(l.triangles), l.Area(), l.samples)
+}
+
+// Area returns the surface area of the mesh.
+func (l *MeshLight) Area() float64 {
+	if len(l.areas) == 0 {
+		return 0
+	}
+	return l.areas[len(l.areas)-1]
+}
+
+// Intensity returns black, the light arriving at a point comes from the samples of the mesh.
+func (l *MeshLight) Intensity() color.Color {
+	return color.Black()
+}
+
+// Ambient returns black, the light of the mesh falls off with the distance, so it adds nothing to the ambient term.
+func (l *MeshLight) Ambient(point tuple.Tuple) color.Color {
+	return color.Black()
+}
+
+// Contains reports whether the shape is one of the triangles of the light.
+func (l *MeshLight) Contains(shape shapes.Shape) bool {
+	return l.members[shape]
+}
+
+func (l *MeshLight) Samples(point tuple.Tuple) []Sample {
+	n := l.samples * l.samples
+	if len(l.triangles) == 0 {
+		return []Sample{{Direction: tuple.NewVector(0, 1, 0), Distance: 0, Intensity: color.Black()}}
+	}
+
+	area := l.Area()
+	result := make([]Sample, 0, n)
+	for i := 0; i < n; i++ {
+		// the samples are spread evenly over the area, each one lands in a random spot of its share.
+		target := (float64(i) + rand.Float64()) / float64(n) * area
+		t := l.triangles[min(sort.SearchFloat64s(l.areas, target), len(l.triangles)-1)]
+
+		// uniform point of the triangle from two random numbers.
+		su, v := math.Sqrt(rand.Float64()), rand.Float64()
+		position := tuple.Add(t.p1, tuple.Add(t.e1.Scalar(su*(1-v)), t.e2.Scalar(su*v)))
+
+		s := l.sample(position, point, t.emission)
+		// the light of a small patch of the surface falls off with the squared distance and
+		// shrinks as it is seen from the side, the triangles glow on both sides.
+		cos := math.Abs(tuple.Dot(t.normal, s.Direction))
+		s.Intensity = s.Intensity.Scalar(cos * area / math.Max(s.Distance*s.Distance, utils.EPSILON))
+		// the shadow ray would hit the light itself.
+		s.Distance -= shadowBias
+		result = append(result, s)
+	}
+	return result
+}
+
+// shadowBias keeps the shadow rays of surface lights from hitting the light's own surface.
+const shadowBias = 1e-6
@@ -0,0 +1,151 @@
+package light
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// a square of the given size, facing down at the given height.
+func emissiveSquare(size, height float64) *shapes.Model {
+	m := shapes.NewModel(`v -0.5 0 -0.5
+v 0.5 0 -0.5
+v 0.5 0 0.5
+v -0.5 0 0.5
+f 1 2 3 4
+`)
+	mat := materials.DefaultMaterial()
+	mat.Emission = color.New(1, 0.5, 0)
+	m.SetMaterial(mat)
+	m.SetTransform(matrix.Multiply(matrix.Translation(0, height, 0), matrix.Scaling(size, 1, size)))
+	return m
+}
+
+func TestMeshLight(t *testing.T) {
+	m := emissiveSquare(4, 2)
+	l := NewMeshLight(m.Triangles(), 3)
+
+	if !utils.FloatEquals(l.Area(), 16) {
+		t.Errorf("expected an area of 16, got %f", l.Area())
+	}
+	if !l.Intensity().Equal(color.Black()) {
+		t.Errorf("expected no constant intensity, got %s", l.Intensity())
+	}
+	for _, tri := range m.Triangles() {
+		if !l.Contains(tri) {
+			t.Errorf("expected the light to contain the triangles of the model")
+		}
+	}
+	if l.Contains(m) || l.Contains(shapes.NewSphere()) {
+		t.Errorf("expected the light to contain only the triangles")
+	}
+
+	point := tuple.NewPoint(0, 0, 0)
+	samples := l.Samples(point)
+	if len(samples) != 9 {
+		t.Errorf("expected 9 samples, got %d", len(samples))
+	}
+	for _, s := range samples {
+		p := samplePosition(point, s)
+		if math.Abs(p.Y-2) > 1e-4 || math.Abs(p.X) > 2 || math.Abs(p.Z) > 2 {
+			t.Errorf("sample %s is outside of the light", p)
+		}
+		if s.Intensity.B != 0 || s.Intensity.R <= 0 {
+			t.Errorf("expected the color of the emission, got %s", s.Intensity)
+		}
+	}
+}
+
+func TestMeshLightIntensity(t *testing.T) {
+	// A small light far away is like a point light, its intensity falls off with the squared distance
+	l := NewMeshLight(emissiveSquare(0.01, 10).Triangles(), 2)
+	expected := 0.01 * 0.01 / 100.0
+
+	for _, s := range l.Samples(tuple.NewPoint(0, 0, 0)) {
+		if math.Abs(s.Intensity.R-expected) > expected*0.01 {
+			t.Errorf("expected an intensity of %g, got %g", expected, s.Intensity.R)
+		}
+	}
+
+	// Seen from the side the light is dimmer
+	side := l.Samples(tuple.NewPoint(10, 10, 0))[0]
+	if side.Intensity.R > expected*0.01 {
+		t.Errorf("expected almost no light from the side, got %g", side.Intensity.R)
+	}
+}
+
+func TestLightingWithMeshLight(t *testing.T) {
+	l := NewMeshLight(emissiveSquare(0.01, 10).Triangles(), 2)
+	s := shapes.NewSphere()
+	mat := materials.DefaultMaterial()
+	mat.Ambient = 1
+	s.SetMaterial(mat)
+	point := tuple.NewPoint(0, -1000, 0)
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 1, 0)
+
+	// A surface far from the light gets almost no light, not the emission as ambient
+	result := Lighting(s, l, point, eyeV, normalV, Unblocked)
+	if result.R > 1e-6 || result.G > 1e-6 || result.B > 1e-6 {
+		t.Errorf("expected almost no light far from the mesh, got %s", result)
+	}
+	result = Lighting(s, l, point, eyeV, normalV, hidden)
+	if !result.Equal(color.Black()) {
+		t.Errorf("expected no ambient light in shadow, got %s", result)
+	}
+}
+
+func TestLightingPartlyHiddenMeshLight(t *testing.T) {
+	// Every sample of the mesh has its own intensity, only the ones that are seen light the point
+	l := NewMeshLight(emissiveSquare(4, 2).Triangles(), 4)
+	s := shapes.NewSphere()
+	mat := s.Material()
+	mat.Ambient = 0
+	point := tuple.NewPoint(0, 0, 0)
+	eyeV := tuple.NewVector(0, 1, 0)
+	normalV := tuple.NewVector(0, 1, 0)
+
+	var tested int
+	var expected color.Color
+	visible := func(sample Sample) bool {
+		tested++
+		if sample.Direction.X < 0 {
+			return false
+		}
+		expected = color.Add(expected, direct(mat, color.White(), sample, eyeV, normalV))
+		return true
+	}
+	result := Lighting(s, l, point, eyeV, normalV, visible)
+	expected = expected.Scalar(1.0 / 16)
+	if tested != 16 || !result.Equal(expected) {
+		t.Errorf("expected %s from the seen samples, got %s", expected, result)
+	}
+}
+
+func TestMeshLightDegenerate(t *testing.T) {
+	mat := materials.DefaultMaterial()
+	mat.Emission = color.White()
+	m := shapes.NewModel(`v 0 0 0
+v 1 0 0
+v 2 0 0
+f 1 2 3
+`)
+	m.SetMaterial(mat)
+	l := NewMeshLight(m.Triangles(), 2)
+
+	// Triangles without area can't emit light
+	if l.Area() != 0 || l.Contains(m.Triangles()[0]) {
+		t.Errorf("expected the degenerate triangle to be skipped, got %s", l)
+	}
+	for _, s := range l.Samples(tuple.NewPoint(0, 1, 0)) {
+		if !s.Intensity.Equal(color.Black()) {
+			t.Errorf("expected no light, got %s", s.Intensity)
+		}
+	}
+}
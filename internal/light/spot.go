@@ -0,0 +1,72 @@
+package light
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// SpotLight is a point light that shines in a cone.
+// Inside the inner angle the light has full intensity, it fades out smoothly towards the outer angle.
+// The angles are measured from the axis of the cone in radians.
+type SpotLight struct {
+	common
+	position  tuple.Tuple
+	direction tuple.Tuple // the axis of the cone.
+	intensity color.Color
+	// cosines of the angles, they are compared with the cosine of the angle between the axis and the point.
+	cosInner, cosOuter float64
+}
+
+func NewSpotLight(position, direction tuple.Tuple, inner, outer float64, intensity color.Color) *SpotLight {
+	outer = math.Max(inner, outer)
+	return &SpotLight{
+		position:  position,
+		direction: direction.Normalize(),
+		intensity: intensity,
+		cosInner:  math.Cos(inner),
+		cosOuter:  math.Cos(outer),
+	}
+}
+
+func (l *SpotLight) String() string {
+	return fmt.Sprintf("SpotLight(position: %f, direction: %f, inner: %f, outer: %f, intensity: %f)",
+		l.position, l.direction, math.Acos(l.cosInner), math.Acos(l.cosOuter), l.intensity)
+}
+
+func (l *SpotLight) Position() tuple.Tuple {
+	return l.position
+}
+
+func (l *SpotLight) Intensity() color.Color {
+	return l.intensity
+}
+
+// Ambient is not limited to the cone, the ambient term stands for the light bouncing around the scene.
+func (l *SpotLight) Ambient(point tuple.Tuple) color.Color {
+	return l.ambient(l.position, point, l.intensity)
+}
+
+func (l *SpotLight) Samples(point tuple.Tuple) []Sample {
+	sample := l.sample(l.position, point, l.intensity)
+	cos := tuple.Dot(sample.Direction.Negate(), l.direction)
+	sample.Intensity = sample.Intensity.Scalar(l.falloff(cos))
+	return []Sample{sample}
+}
+
+// The fraction of the intensity reaching a point at an angle with the given cosine from the axis.
+func (l *SpotLight) falloff(cos float64) float64 {
+	if cos >= l.cosInner {
+		return 1
+	}
+	if cos <= l.cosOuter {
+		return 0
+	}
+	return smoothstep((cos - l.cosOuter) / (l.cosInner - l.cosOuter))
+}
+
+func smoothstep(x float64) float64 {
+	return x * x * (3 - 2*x)
+}
@@ -0,0 +1,54 @@
+package light
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestSpotLightSamples(t *testing.T) {
+	// A spot light pointing down, full intensity up to 30°, no light beyond 45°.
+	l := NewSpotLight(tuple.NewPoint(0, 10, 0), tuple.NewVector(0, -1, 0), math.Pi/6, math.Pi/4, color.New(1, 1, 1))
+
+	var tests = []struct {
+		point    tuple.Tuple
+		expected float64
+	}{
+		{point: tuple.NewPoint(0, 0, 0), expected: 1},
+		{point: tuple.NewPoint(5, 0, 0), expected: 1},
+		// the middle of the falloff, at 37.5°
+		{point: tuple.NewPoint(10*math.Tan(math.Pi*37.5/180), 0, 0), expected: 0.5639},
+		{point: tuple.NewPoint(10, 0, 0), expected: 0},
+		{point: tuple.NewPoint(0, 20, 0), expected: 0},
+	}
+
+	for _, test := range tests {
+		samples := l.Samples(test.point)
+		if len(samples) != 1 {
+			t.Errorf("expected 1 sample, got %d", len(samples))
+		}
+		if result := samples[0].Intensity.R; math.Abs(result-test.expected) > 0.001 {
+			t.Errorf("spot light intensity at %s expected %f, got %f", test.point, test.expected, result)
+		}
+	}
+}
+
+func TestSmoothstep(t *testing.T) {
+	var tests = []struct {
+		x, expected float64
+	}{
+		{x: 0, expected: 0},
+		{x: 0.5, expected: 0.5},
+		{x: 1, expected: 1},
+		{x: 0.25, expected: 0.15625},
+	}
+
+	for _, test := range tests {
+		if result := smoothstep(test.x); !utils.FloatEquals(result, test.expected) {
+			t.Errorf("smoothstep(%f) expected %f, got %f", test.x, test.expected, result)
+		}
+	}
+}
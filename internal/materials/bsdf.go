@@ -0,0 +1,169 @@
+package materials
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// BSDF describes how a surface scatters light. The intensities follow the conventions of the Phong model,
+// a white surface facing a white light reflects the light's full intensity.
+type BSDF interface {
+	// Evaluate returns the light scattered towards the eye, when light of the given intensity arrives from lightV.
+	// Coloring is the color of the surface at the point.
+	Evaluate(mat *Material, coloring color.Color, lightV, eyeV, normalV tuple.Tuple, intensity color.Color) color.Color
+	// Albedo returns the fraction of the light that is scattered diffusely, in every direction.
+	Albedo(mat *Material, coloring color.Color) color.Color
+	// Microfacet returns the normal that reflections and refractions bounce off. Rough surfaces tilt it randomly,
+	// which blurs the reflections. u and v are random numbers between 0 and 1.
+	Microfacet(normalV, eyeV tuple.Tuple, u, v float64) tuple.Tuple
+	// Reflectance returns the share of the light arriving from the mirror direction that is reflected towards the eye,
+	// metals reflect in their own color. Black means the surface has no reflections.
+	Reflectance(mat *Material, coloring color.Color, normalV, eyeV tuple.Tuple) color.Color
+}
+
+// Phong is the classic model, the highlight is controlled by the Specular and Shininess of the material.
+// Reflections and refractions are perfectly sharp.
+type Phong struct{}
+
+func (b Phong) String() string {
+	return "Phong()"
+}
+
+func (b Phong) Evaluate(mat *Material, coloring color.Color, lightV, eyeV, normalV tuple.Tuple, intensity color.Color) color.Color {
+	effectiveColor := color.HadamardProduct(coloring, intensity)
+	// lightDotNormal represents the cosine of the angle between the
+	// light vector and the normal vector. A negative number means the
+	// light is on the other side of the surface.
+	lightDotNormal := tuple.Dot(lightV, normalV)
+	if lightDotNormal < 0 {
+		return color.Black()
+	}
+
+	// compute the diffuse contribution
+	diffuse := effectiveColor.Scalar(mat.Diffuse * lightDotNormal)
+	// reflectDotEye represents the cosine of the angle between the
+	// reflection vector and the eye vector. A negative number means the
+	// light reflects away from the eye.
+	reflectV := tuple.Reflect(lightV.Negate(), normalV)
+	reflectDotEye := tuple.Dot(reflectV, eyeV)
+	if reflectDotEye <= 0 {
+		return diffuse
+	}
+
+	// compute the specular contribution
+	factor := math.Pow(reflectDotEye, mat.Shininess)
+	specular := intensity.Scalar(mat.Specular * factor)
+
+	return color.Add(diffuse, specular)
+}
+
+func (b Phong) Albedo(mat *Material, coloring color.Color) color.Color {
+	return coloring.Scalar(mat.Diffuse)
+}
+
+func (b Phong) Microfacet(normalV, eyeV tuple.Tuple, u, v float64) tuple.Tuple {
+	return normalV
+}
+
+// Reflectance is the Reflective of the material, the reflections keep their color.
+func (b Phong) Reflectance(mat *Material, coloring color.Color, normalV, eyeV tuple.Tuple) color.Color {
+	return color.White().Scalar(mat.Reflective)
+}
+
+// GGX is the Cook-Torrance microfacet model with the GGX (Trowbridge-Reitz) distribution.
+// The surface is made of tiny mirrors, the rougher it is the more they are tilted, spreading the highlight.
+// The highlight gets stronger at grazing angles, like on real surfaces (Fresnel effect).
+// The Diffuse of the material scales the diffuse part, Specular, Shininess and Reflective are not used.
+type GGX struct {
+	Roughness float64 // 0 is polished, 1 is completely rough.
+	Metallic  float64 // metals have no diffuse part, they reflect in their own color.
+	F0        float64 // the reflectance of non-metals seen head-on, 0.04 for most plastics and glass.
+}
+
+func (b GGX) String() string {
+	return fmt.Sprintf("GGX(roughness: %f, metallic: %f, f0: %f)", b.Roughness, b.Metallic, b.F0)
+}
+
+// the width of the distribution, squaring the roughness makes it perceptually linear.
+// Perfectly smooth surfaces would make the highlight infinitely small, so it is kept above a minimum.
+func (b GGX) alpha() float64 {
+	return math.Max(b.Roughness*b.Roughness, 1e-3)
+}
+
+func (b GGX) Evaluate(mat *Material, coloring color.Color, lightV, eyeV, normalV tuple.Tuple, intensity color.Color) color.Color {
+	nDotL := tuple.Dot(normalV, lightV)
+	nDotV := tuple.Dot(normalV, eyeV)
+	if nDotL <= 0 || nDotV <= 0 {
+		return color.Black()
+	}
+
+	halfway := tuple.Add(lightV, eyeV).Normalize()
+	nDotH := math.Max(tuple.Dot(normalV, halfway), 0)
+	vDotH := math.Max(tuple.Dot(eyeV, halfway), 0)
+
+	a2 := b.alpha() * b.alpha()
+	// the share of the microfacets facing the halfway vector.
+	d := nDotH*nDotH*(a2-1) + 1
+	distribution := a2 / (math.Pi * d * d)
+	// the share of the microfacets that are neither hidden from the light nor from the eye (Smith).
+	geometry := smithG1(nDotL, a2) * smithG1(nDotV, a2)
+	fresnel := schlick(b.tint(coloring), b.F0, b.Metallic, vDotH)
+
+	// Cook-Torrance: D * G * F / (4 * n.l * n.v), multiplied by n.l and by π to match the Phong conventions.
+	specular := fresnel.Scalar(distribution * geometry * math.Pi / (4 * nDotV))
+	diffuse := color.HadamardProduct(b.Albedo(mat, coloring), color.Subtract(color.White(), fresnel)).Scalar(nDotL)
+
+	return color.HadamardProduct(color.Add(diffuse, specular), intensity)
+}
+
+func smithG1(cos, a2 float64) float64 {
+	return 2 * cos / (cos + math.Sqrt(a2+(1-a2)*cos*cos))
+}
+
+// Schlick's approximation of the Fresnel reflectance, the surface reflects more at grazing angles.
+// Metals start from their own color, non-metals from f0.
+func schlick(tint color.Color, f0, metallic, cos float64) color.Color {
+	base := color.Add(color.New(f0, f0, f0).Scalar(1-metallic), tint.Scalar(metallic))
+	weight := math.Pow(1-cos, 5)
+	return color.Add(base.Scalar(1-weight), color.White().Scalar(weight))
+}
+
+func (b GGX) Albedo(mat *Material, coloring color.Color) color.Color {
+	return coloring.Scalar(mat.Diffuse * (1 - b.Metallic))
+}
+
+// Microfacet picks a normal following the GGX distribution, the tilt grows with the roughness.
+func (b GGX) Microfacet(normalV, eyeV tuple.Tuple, u, v float64) tuple.Tuple {
+	if b.Roughness == 0 {
+		return normalV
+	}
+
+	a := b.alpha()
+	theta := math.Atan(a * math.Sqrt(u/(1-u)))
+	phi := 2 * math.Pi * v
+	t, s := tuple.Basis(normalV)
+	sinTheta := math.Sin(theta)
+	m := tuple.Add(
+		tuple.Add(t.Scalar(sinTheta*math.Cos(phi)), s.Scalar(sinTheta*math.Sin(phi))),
+		normalV.Scalar(math.Cos(theta)),
+	)
+	// microfacets facing away from the eye can't be seen.
+	if tuple.Dot(m, eyeV) <= 0 {
+		return normalV
+	}
+	return m
+}
+
+// Reflectance follows the Fresnel effect, every surface reflects, more at grazing angles.
+// Metals reflect strongly in their own color, non-metals weakly in white.
+func (b GGX) Reflectance(mat *Material, coloring color.Color, normalV, eyeV tuple.Tuple) color.Color {
+	return schlick(b.tint(coloring), b.F0, b.Metallic, math.Max(tuple.Dot(normalV, eyeV), 0))
+}
+
+// the color of the reflections, metals reflect in their own color.
+func (b GGX) tint(coloring color.Color) color.Color {
+	return color.Add(color.White().Scalar(1-b.Metallic), coloring.Scalar(b.Metallic))
+}
@@ -0,0 +1,152 @@
+package materials
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestPhongEvaluate(t *testing.T) {
+	mat := DefaultMaterial()
+	normalV := tuple.NewVector(0, 0, -1)
+	var tests = []struct {
+		name         string
+		lightV, eyeV tuple.Tuple
+		expected     color.Color
+	}{
+		{
+			name:     "eye between the light and the surface",
+			lightV:   tuple.NewVector(0, 0, -1),
+			eyeV:     tuple.NewVector(0, 0, -1),
+			expected: color.New(1.8, 1.8, 1.8),
+		},
+		{
+			name:     "eye in the path of the reflection",
+			lightV:   tuple.NewVector(0, math.Sqrt(2)/2, -math.Sqrt(2)/2),
+			eyeV:     tuple.NewVector(0, -math.Sqrt(2)/2, -math.Sqrt(2)/2),
+			expected: color.New(1.5363961030678928, 1.5363961030678928, 1.5363961030678928),
+		},
+		{
+			name:     "light behind the surface",
+			lightV:   tuple.NewVector(0, 0, 1),
+			eyeV:     tuple.NewVector(0, 0, -1),
+			expected: color.Black(),
+		},
+	}
+
+	for _, test := range tests {
+		result := (Phong{}).Evaluate(mat, color.White(), test.lightV, test.eyeV, normalV, color.White())
+		if !result.Equal(test.expected) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestGGXEvaluate(t *testing.T) {
+	mat := DefaultMaterial()
+	normalV := tuple.NewVector(0, 1, 0)
+	eyeV := tuple.NewVector(0, 1, 0)
+	lightV := tuple.NewVector(0, math.Sqrt(2)/2, math.Sqrt(2)/2)
+	red := color.New(1, 0, 0)
+
+	if result := (GGX{Roughness: 0.5, F0: 0.04}).Evaluate(mat, red, tuple.NewVector(0, -1, 0), eyeV, normalV, color.White()); !result.Equal(color.Black()) {
+		t.Errorf("light behind the surface: expected black, got %s", result)
+	}
+
+	plastic := (GGX{Roughness: 0.5, F0: 0.04}).Evaluate(mat, red, lightV, eyeV, normalV, color.White())
+	if plastic.R <= plastic.G || plastic.G <= 0 {
+		t.Errorf("plastic: expected a red diffuse part and a white highlight, got %s", plastic)
+	}
+
+	// metals have no diffuse part, their highlight takes their color.
+	metal := (GGX{Roughness: 0.5, Metallic: 1, F0: 0.04}).Evaluate(mat, red, lightV, eyeV, normalV, color.White())
+	if metal.R <= 0 || metal.G > 0.01 {
+		t.Errorf("metal: expected a red highlight, got %s", metal)
+	}
+
+	// the highlight is sharper on smooth surfaces.
+	mirror := tuple.NewVector(0, 1, 0)
+	smooth := (GGX{Roughness: 0.1, F0: 0.04}).Evaluate(mat, color.Black(), mirror, eyeV, normalV, color.White())
+	rough := (GGX{Roughness: 0.8, F0: 0.04}).Evaluate(mat, color.Black(), mirror, eyeV, normalV, color.White())
+	if smooth.R <= rough.R {
+		t.Errorf("expected a smooth surface to have a stronger highlight, got %s and %s", smooth, rough)
+	}
+}
+
+func TestGGXAlbedo(t *testing.T) {
+	mat := DefaultMaterial()
+	var tests = []struct {
+		bsdf     GGX
+		expected color.Color
+	}{
+		{bsdf: GGX{}, expected: color.New(0.9, 0.45, 0)},
+		{bsdf: GGX{Metallic: 0.5}, expected: color.New(0.45, 0.225, 0)},
+		{bsdf: GGX{Metallic: 1}, expected: color.Black()},
+	}
+
+	for _, test := range tests {
+		if result := test.bsdf.Albedo(mat, color.New(1, 0.5, 0)); !result.Equal(test.expected) {
+			t.Errorf("%s albedo: expected %s, got %s", test.bsdf, test.expected, result)
+		}
+	}
+}
+
+func TestReflectance(t *testing.T) {
+	mat := DefaultMaterial()
+	coloring := color.New(1, 0.5, 0)
+	normalV := tuple.NewVector(0, 1, 0)
+	grazing := tuple.NewVector(0, 0, -1)
+	var tests = []struct {
+		bsdf     BSDF
+		eyeV     tuple.Tuple
+		expected color.Color
+	}{
+		{bsdf: Phong{}, eyeV: normalV, expected: color.Black()},
+		{bsdf: GGX{F0: 0.04}, eyeV: normalV, expected: color.New(0.04, 0.04, 0.04)},
+		{bsdf: GGX{F0: 0.04}, eyeV: grazing, expected: color.White()},
+		{bsdf: GGX{Metallic: 1, F0: 0.04}, eyeV: normalV, expected: coloring},
+		{bsdf: GGX{Metallic: 0.5}, eyeV: normalV, expected: color.New(0.5, 0.375, 0.25)},
+		{bsdf: GGX{Metallic: 1, Roughness: 1}, eyeV: grazing, expected: color.White()},
+	}
+
+	for _, test := range tests {
+		if result := test.bsdf.Reflectance(mat, coloring, normalV, test.eyeV); !result.Equal(test.expected) {
+			t.Errorf("%s reflectance: expected %s, got %s", test.bsdf, test.expected, result)
+		}
+	}
+
+	// Phong reflects as much as the material is reflective
+	mat.Reflective = 0.5
+	if result := (Phong{}).Reflectance(mat, coloring, normalV, normalV); !result.Equal(color.New(0.5, 0.5, 0.5)) {
+		t.Errorf("Phong reflectance: expected the reflective of the material, got %s", result)
+	}
+}
+
+func TestGGXMicrofacet(t *testing.T) {
+	normalV := tuple.NewVector(0, 1, 0)
+	eyeV := tuple.NewVector(0, math.Sqrt(2)/2, -math.Sqrt(2)/2)
+
+	if result := (GGX{}).Microfacet(normalV, eyeV, 0.3, 0.7); !result.Equal(normalV) {
+		t.Errorf("smooth surface: expected the normal, got %s", result)
+	}
+
+	var tilted bool
+	for _, u := range []float64{0.1, 0.5, 0.9} {
+		for _, v := range []float64{0, 0.25, 0.5, 0.75} {
+			m := (GGX{Roughness: 0.5}).Microfacet(normalV, eyeV, u, v)
+			if !utils.FloatEquals(m.Magnitude(), 1) {
+				t.Errorf("expected a unit vector, got %s", m)
+			}
+			if tuple.Dot(m, eyeV) <= 0 {
+				t.Errorf("expected the microfacet to face the eye, got %s", m)
+			}
+			tilted = tilted || !m.Equal(normalV)
+		}
+	}
+	if !tilted {
+		t.Errorf("expected a rough surface to tilt the normal")
+	}
+}
@@ -0,0 +1,10 @@
+package materials
+
+// Bump gives a surface detail without extra geometry by tilting its normals.
+type Bump struct {
+	// Heights are read from the brightness of the pattern (a bump map), the surface looks raised where it is white.
+	// With NormalMap the colors of the pattern are tangent space normals, usually an image texture.
+	Pattern   *Pattern
+	NormalMap bool
+	Strength  float64 // how much the normals are tilted, 1 keeps the heights or normals of the pattern as they are.
+}
@@ -11,6 +11,7 @@ import (
 
 type Material struct {
 	pattern *Pattern
+	bsdf    BSDF
 	Ambient, Diffuse, Specular, Shininess, Reflective, Transparency,
 	RefractiveIndex float64 // refractivity of the material, here are some refractive indices:
 	//  Vacuum: 1
@@ -18,12 +19,23 @@ type Material struct {
 	//  Water: 1.333
 	//  Glass: 1.52
 	//  Diamond: 2.417
+	CastsShadow    bool // the object blocks the light from reaching other objects.
+	ReceivesShadow bool // other objects can cast shadows on the object.
+	// Emission is the light given off by the surface, it is visible regardless of the lights.
+	// Black by default, brighter than white for strong lights.
+	Emission color.Color
+	Bump     *Bump // nil for smooth surfaces.
 }
 
 func (mat *Material) ColorAt(pos tuple.Tuple) color.Color {
 	return mat.pattern.colorAt(pos)
 }
 
+// ColorAtUV returns the color of a flat pattern at the texture coordinates.
+func (mat *Material) ColorAtUV(u, v float64) color.Color {
+	return mat.pattern.uvAt(u, v)
+}
+
 func (mat *Material) String() string {
 	return fmt.Sprintf("Material(Ambient: %f, Diffuse: %f, Specular: %f, Shininess: %f,)",
 		mat.Ambient,
@@ -43,6 +55,8 @@ func DefaultMaterial() *Material {
 		Reflective:      0.0,
 		Transparency:    0.0,
 		RefractiveIndex: 1.0,
+		CastsShadow:     true,
+		ReceivesShadow:  true,
 	}
 }
 
@@ -56,6 +70,8 @@ func NewMaterial(c color.Color, ambient, diffuse, specular, shininess, reflectiv
 		Reflective:      reflective,
 		Transparency:    transparency,
 		RefractiveIndex: refractiveIndex,
+		CastsShadow:     true,
+		ReceivesShadow:  true,
 	}
 }
 
@@ -71,6 +87,18 @@ func (s *Material) Pattern() *Pattern {
 	return s.pattern
 }
 
+// BSDF returns how the surface scatters light, Phong by default.
+func (s *Material) BSDF() BSDF {
+	if s.bsdf == nil {
+		return Phong{}
+	}
+	return s.bsdf
+}
+
+func (s *Material) SetBSDF(bsdf BSDF) {
+	s.bsdf = bsdf
+}
+
 func (s *Material) Transform() matrix.Matrix {
 	return s.pattern.transform
 }
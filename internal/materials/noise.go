@@ -0,0 +1,188 @@
+package materials
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// NoiseBasis is the function that is layered into octaves.
+type NoiseBasis int
+
+const (
+	PerlinNoise  NoiseBasis = iota // Ken Perlin's improved noise.
+	SimplexNoise                   // smoother and with fewer grid artifacts than Perlin.
+)
+
+// Noise is a coherent, random looking function of space. Nearby points get similar values,
+// which is what makes marble, wood or clouds look natural.
+// Octaves of the basis are summed (fractal Brownian motion), every octave has lacunarity times the
+// frequency and gain times the amplitude of the previous one.
+// The same seed always gives the same noise.
+type Noise struct {
+	Basis      NoiseBasis
+	Octaves    int
+	Lacunarity float64
+	Gain       float64
+	perm       [512]int
+}
+
+func NewNoise(basis NoiseBasis, octaves int, lacunarity, gain float64, seed uint64) *Noise {
+	n := &Noise{Basis: basis, Octaves: octaves, Lacunarity: lacunarity, Gain: gain}
+	random := rand.New(rand.NewPCG(seed, seed))
+	for i, p := range random.Perm(256) {
+		n.perm[i] = p
+		n.perm[i+256] = p
+	}
+	return n
+}
+
+// DefaultNoise returns 4 octaves of Perlin noise, each octave doubles the frequency and halves the amplitude.
+func DefaultNoise() *Noise {
+	return NewNoise(PerlinNoise, 4, 2, 0.5, 0)
+}
+
+// At returns a single octave of the noise at the point, between -1 and 1.
+func (n *Noise) At(p tuple.Tuple) float64 {
+	if n.Basis == SimplexNoise {
+		return n.simplex(p.X, p.Y, p.Z)
+	}
+	return n.perlin(p.X, p.Y, p.Z)
+}
+
+// FBM sums the octaves, the result is between -1 and 1.
+func (n *Noise) FBM(p tuple.Tuple) float64 {
+	return n.octaves(p, func(x float64) float64 { return x })
+}
+
+// Turbulence sums the absolute values of the octaves, the creases make it look like fire or veins.
+// The result is between 0 and 1.
+func (n *Noise) Turbulence(p tuple.Tuple) float64 {
+	return n.octaves(p, math.Abs)
+}
+
+func (n *Noise) octaves(p tuple.Tuple, shape func(float64) float64) float64 {
+	var sum, total float64
+	frequency, amplitude := 1.0, 1.0
+	for i := 0; i < max(n.Octaves, 1); i++ {
+		sum += amplitude * shape(n.At(tuple.NewPoint(p.X*frequency, p.Y*frequency, p.Z*frequency)))
+		total += amplitude
+		frequency *= n.Lacunarity
+		amplitude *= n.Gain
+	}
+	// normalised by the largest possible sum, so the range doesn't depend on the parameters.
+	return sum / total
+}
+
+func (n *Noise) perlin(x, y, z float64) float64 {
+	// the unit cube that contains the point.
+	xi, yi, zi := int(math.Floor(x))&255, int(math.Floor(y))&255, int(math.Floor(z))&255
+	// the position of the point inside the cube.
+	x, y, z = x-math.Floor(x), y-math.Floor(y), z-math.Floor(z)
+	u, v, w := fade(x), fade(y), fade(z)
+
+	p := n.perm
+	a := p[xi] + yi
+	aa, ab := p[a]+zi, p[a+1]+zi
+	b := p[xi+1] + yi
+	ba, bb := p[b]+zi, p[b+1]+zi
+
+	// blends the gradients of the eight corners.
+	return lerp(w,
+		lerp(v,
+			lerp(u, perlinGrad(p[aa], x, y, z), perlinGrad(p[ba], x-1, y, z)),
+			lerp(u, perlinGrad(p[ab], x, y-1, z), perlinGrad(p[bb], x-1, y-1, z))),
+		lerp(v,
+			lerp(u, perlinGrad(p[aa+1], x, y, z-1), perlinGrad(p[ba+1], x-1, y, z-1)),
+			lerp(u, perlinGrad(p[ab+1], x, y-1, z-1), perlinGrad(p[bb+1], x-1, y-1, z-1))))
+}
+
+// 6t^5 - 15t^4 + 10t^3, smooths the blend so the noise has no creases at the cube faces.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// the dot product of the offset with one of 12 gradient directions, picked by the hash.
+func perlinGrad(hash int, x, y, z float64) float64 {
+	h := hash & 15
+	u := y
+	if h < 8 {
+		u = x
+	}
+	v := z
+	if h < 4 {
+		v = y
+	} else if h == 12 || h == 14 {
+		v = x
+	}
+	if h&1 != 0 {
+		u = -u
+	}
+	if h&2 != 0 {
+		v = -v
+	}
+	return u + v
+}
+
+var simplexGrad = [12][3]float64{
+	{1, 1, 0}, {-1, 1, 0}, {1, -1, 0}, {-1, -1, 0},
+	{1, 0, 1}, {-1, 0, 1}, {1, 0, -1}, {-1, 0, -1},
+	{0, 1, 1}, {0, -1, 1}, {0, 1, -1}, {0, -1, -1},
+}
+
+// 3D simplex noise, following Stefan Gustavson's implementation.
+func (n *Noise) simplex(x, y, z float64) float64 {
+	const skew, unskew = 1.0 / 3, 1.0 / 6
+
+	// the simplex cell that contains the point.
+	s := (x + y + z) * skew
+	i, j, k := math.Floor(x+s), math.Floor(y+s), math.Floor(z+s)
+	t := (i + j + k) * unskew
+	x0, y0, z0 := x-(i-t), y-(j-t), z-(k-t)
+
+	// the second and third corners of the tetrahedron, depending on the order of the coordinates.
+	var i1, j1, k1, i2, j2, k2 float64
+	switch {
+	case x0 >= y0 && y0 >= z0:
+		i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+	case x0 >= y0 && x0 >= z0:
+		i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+	case x0 >= y0:
+		i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+	case y0 < z0:
+		i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+	case x0 < z0:
+		i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+	default:
+		i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+	}
+
+	corners := [4][3]float64{
+		{x0, y0, z0},
+		{x0 - i1 + unskew, y0 - j1 + unskew, z0 - k1 + unskew},
+		{x0 - i2 + 2*unskew, y0 - j2 + 2*unskew, z0 - k2 + 2*unskew},
+		{x0 - 1 + 3*unskew, y0 - 1 + 3*unskew, z0 - 1 + 3*unskew},
+	}
+	offsets := [4][3]int{{0, 0, 0}, {int(i1), int(j1), int(k1)}, {int(i2), int(j2), int(k2)}, {1, 1, 1}}
+
+	ii, jj, kk := int(i)&255, int(j)&255, int(k)&255
+	var sum float64
+	for c, corner := range corners {
+		// every corner contributes inside a sphere around it.
+		falloff := 0.6 - corner[0]*corner[0] - corner[1]*corner[1] - corner[2]*corner[2]
+		if falloff <= 0 {
+			continue
+		}
+		o := offsets[c]
+		g := simplexGrad[n.perm[ii+o[0]+n.perm[jj+o[1]+n.perm[kk+o[2]]]]%12]
+		falloff *= falloff
+		sum += falloff * falloff * (g[0]*corner[0] + g[1]*corner[1] + g[2]*corner[2])
+	}
+	// scales the result to -1 to 1.
+	return 32 * sum
+}
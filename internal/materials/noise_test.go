@@ -0,0 +1,100 @@
+package materials
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+func samplePoints() []tuple.Tuple {
+	var points []tuple.Tuple
+	for i := 0; i < 200; i++ {
+		f := float64(i)
+		points = append(points, tuple.NewPoint(f*0.37-20, f*0.13+3.1, -f*0.29))
+	}
+	return points
+}
+
+func TestNoiseRange(t *testing.T) {
+	for _, basis := range []NoiseBasis{PerlinNoise, SimplexNoise} {
+		noise := NewNoise(basis, 4, 2, 0.5, 7)
+		var varied bool
+		for _, p := range samplePoints() {
+			at, fbm, turbulence := noise.At(p), noise.FBM(p), noise.Turbulence(p)
+			if at < -1 || at > 1 || fbm < -1 || fbm > 1 {
+				t.Errorf("noise %d at %s: expected values between -1 and 1, got %f and %f", basis, p, at, fbm)
+			}
+			if turbulence < 0 || turbulence > 1 {
+				t.Errorf("noise %d at %s: expected turbulence between 0 and 1, got %f", basis, p, turbulence)
+			}
+			varied = varied || at > 0.1 || at < -0.1
+		}
+		if !varied {
+			t.Errorf("noise %d: expected the noise to vary", basis)
+		}
+	}
+}
+
+func TestPerlinNoiseAtLatticePoints(t *testing.T) {
+	// Perlin noise is zero at the corners of the unit cubes
+	noise := NewNoise(PerlinNoise, 1, 2, 0.5, 3)
+	for _, p := range []tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(1, 2, 3), tuple.NewPoint(-4, 7, -1)} {
+		if result := noise.At(p); result != 0 {
+			t.Errorf("expected 0 at %s, got %f", p, result)
+		}
+	}
+}
+
+func TestNoiseSeed(t *testing.T) {
+	for _, basis := range []NoiseBasis{PerlinNoise, SimplexNoise} {
+		a, b, c := NewNoise(basis, 3, 2, 0.5, 1), NewNoise(basis, 3, 2, 0.5, 1), NewNoise(basis, 3, 2, 0.5, 2)
+		var differs bool
+		for _, p := range samplePoints() {
+			if a.FBM(p) != b.FBM(p) {
+				t.Errorf("noise %d at %s: expected the same seed to give the same noise", basis, p)
+			}
+			differs = differs || a.FBM(p) != c.FBM(p)
+		}
+		if !differs {
+			t.Errorf("noise %d: expected different seeds to give different noise", basis)
+		}
+	}
+}
+
+func TestNoisePattern(t *testing.T) {
+	a, b := color.Black(), color.White()
+	for _, kind := range []NoiseKind{Clouds, Turbulent, Marble, Wood} {
+		pattern := NewNoisePattern(kind, DefaultNoise(), a, b)
+		for _, p := range samplePoints() {
+			result := pattern.colorAt(p)
+			if result.R < 0 || result.R > 1 || result.R != result.G || result.G != result.B {
+				t.Errorf("noise pattern %d at %s: expected a blend of the colors, got %s", kind, p, result)
+			}
+		}
+	}
+}
+
+func TestPerturbedPattern(t *testing.T) {
+	stripes := newStripePattern(color.White(), color.Black())
+
+	// Without any perturbation the pattern is unchanged
+	unchanged := NewPerturbedPattern(stripes, DefaultNoise(), 0)
+	var changed bool
+	perturbed := NewPerturbedPattern(stripes, DefaultNoise(), 0.5)
+	for _, p := range samplePoints() {
+		if result, expected := unchanged.colorAt(p), stripes.colorAt(p); !result.Equal(expected) {
+			t.Errorf("at %s: expected %s, got %s", p, expected, result)
+		}
+		changed = changed || !perturbed.colorAt(p).Equal(stripes.colorAt(p))
+	}
+	if !changed {
+		t.Errorf("expected the perturbation to move the stripes")
+	}
+
+	// Flat patterns stay flat
+	checker := NewUVCheckerPattern(2, 2, color.White(), color.Black(), SphericalMapping)
+	if p := NewPerturbedPattern(checker, DefaultNoise(), 0.1); !p.UVMapped() || p.Mapping() != SphericalMapping {
+		t.Errorf("expected the perturbed pattern to keep the mapping")
+	}
+}
@@ -22,7 +22,47 @@ const (
 
 type Pattern struct {
 	transform matrix.Matrix
-	colorAt   func(tuple.Tuple) color.Color // function that determines the color at a point
+	colorAt   func(tuple.Tuple) color.Color  // function that determines the color at a point
+	uvAt      func(u, v float64) color.Color // determines the color at texture coordinates, nil for 3D patterns
+	mapping   Mapping
+}
+
+// UVMapped reports whether the pattern is flat and needs to be wrapped around the object.
+func (p *Pattern) UVMapped() bool {
+	return p.uvAt != nil
+}
+
+// Mapping returns how the flat pattern is wrapped around the object.
+func (p *Pattern) Mapping() Mapping {
+	return p.mapping
+}
+
+func (p *Pattern) Transform() matrix.Matrix {
+	return p.transform
+}
+
+// ColorAt returns the color at a point in pattern space.
+func (p *Pattern) ColorAt(point tuple.Tuple) color.Color {
+	return p.colorAt(point)
+}
+
+// ColorAtUV returns the color of a flat pattern at the texture coordinates.
+func (p *Pattern) ColorAtUV(u, v float64) color.Color {
+	return p.uvAt(u, v)
+}
+
+// SetTransform sets the transform of a pattern that is the input of another pattern.
+// The transform of the outermost pattern is set on the material.
+func (p *Pattern) SetTransform(transform matrix.Matrix) {
+	p.transform = transform
+}
+
+// returns the color at a point in the space of the parent pattern, the pattern applies its own transform.
+func (p *Pattern) at(point tuple.Tuple) color.Color {
+	if p.transform != matrix.DefaultTransform() {
+		point = tuple.Multiply(p.transform.Inverse(), point)
+	}
+	return p.colorAt(point)
 }
 
 func NewPattern(pattern PatternType, colors ...color.Color) *Pattern {
@@ -54,63 +94,230 @@ func newBasePattern(c color.Color) *Pattern {
 
 // returns a striped patter with two colors
 func newStripePattern(a, b color.Color) *Pattern {
+	return newTwoInputPattern(stripe, newBasePattern(a), newBasePattern(b))
+}
+
+// returns a gradient pattern with two colors
+func newGradientPattern(a, b color.Color) *Pattern {
+	return newTwoInputPattern(gradient, newBasePattern(a), newBasePattern(b))
+}
+
+// returns a ring pattern with two colors
+func newRingPattern(a, b color.Color) *Pattern {
+	return newTwoInputPattern(ring, newBasePattern(a), newBasePattern(b))
+}
+
+// returns a checker pattern with two colors
+func newCheckerPattern(a, b color.Color) *Pattern {
+	return newTwoInputPattern(checker, newBasePattern(a), newBasePattern(b))
+}
+
+// The layouts of the two input patterns, they return the weight of the second input at a point.
+func stripe(point tuple.Tuple) float64 {
+	if math.Mod(math.Floor(point.X), 2) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func gradient(point tuple.Tuple) float64 {
+	return point.X - math.Floor(point.X)
+}
+
+func ring(point tuple.Tuple) float64 {
+	comp := math.Sqrt(math.Pow(point.X, 2) + math.Pow(point.Z, 2))
+	if math.Mod(math.Floor(comp), 2) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func checker(point tuple.Tuple) float64 {
+	sum := math.Floor(point.X) + math.Floor(point.Y) + math.Floor(point.Z)
+	if math.Mod(sum, 2) == 0 {
+		return 0
+	}
+	return 1
+}
+
+func newTwoInputPattern(weight func(tuple.Tuple) float64, a, b *Pattern) *Pattern {
 	return &Pattern{
 		transform: matrix.DefaultTransform(),
 		colorAt: func(point tuple.Tuple) color.Color {
-			if math.Mod(math.Floor(point.X), 2) == 0 {
-				return a
+			switch w := weight(point); w {
+			case 0:
+				return a.at(point)
+			case 1:
+				return b.at(point)
+			default:
+				return mix(a.at(point), b.at(point), w)
 			}
-			return b
 		},
 	}
 }
 
-// returns a gradient pattern with two colors
-func newGradientPattern(a, b color.Color) *Pattern {
+// NewNestedPattern returns a stripe, gradient, ring or checker pattern that has patterns in place of the colors.
+// A checker of stripes alternates two stripe patterns, each square is striped.
+func NewNestedPattern(pattern PatternType, a, b *Pattern) *Pattern {
+	switch pattern {
+	case Stripe:
+		return newTwoInputPattern(stripe, a, b)
+	case Gradient:
+		return newTwoInputPattern(gradient, a, b)
+	case Ring:
+		return newTwoInputPattern(ring, a, b)
+	case Checker:
+		return newTwoInputPattern(checker, a, b)
+	default:
+		panic(fmt.Errorf("not supported nested pattern: %d", pattern))
+	}
+}
+
+// returns a pattern that mixes the two patterns, a weight of 0 is only a and 1 is only b.
+func NewBlendPattern(a, b *Pattern, weight float64) *Pattern {
+	return newTwoInputPattern(func(tuple.Tuple) float64 { return weight }, a, b)
+}
+
+// returns a pattern that shows a where the mask is black and b where it is white, grays mix them.
+func NewMaskPattern(a, b, mask *Pattern) *Pattern {
+	return newTwoInputPattern(func(point tuple.Tuple) float64 {
+		c := mask.at(point)
+		return math.Min(math.Max((c.R+c.G+c.B)/3, 0), 1)
+	}, a, b)
+}
+
+// Operator combines the colors of two patterns channel by channel.
+type Operator int
+
+const (
+	Add Operator = iota
+	Subtract
+	Multiply
+	Min
+	Max
+)
+
+// returns a pattern that combines the colors of the two patterns with the operator.
+func NewOperatorPattern(op Operator, a, b *Pattern) *Pattern {
+	var combine func(x, y float64) float64
+	switch op {
+	case Add:
+		combine = func(x, y float64) float64 { return x + y }
+	case Subtract:
+		combine = func(x, y float64) float64 { return x - y }
+	case Multiply:
+		combine = func(x, y float64) float64 { return x * y }
+	case Min:
+		combine = math.Min
+	case Max:
+		combine = math.Max
+	default:
+		panic(fmt.Errorf("not supported operator: %d", op))
+	}
+
 	return &Pattern{
 		transform: matrix.DefaultTransform(),
 		colorAt: func(point tuple.Tuple) color.Color {
-			distance := color.Subtract(b, a)
-			fraction := point.X - math.Floor(point.X)
-			return color.Add(a, distance.Scalar(fraction))
+			x, y := a.at(point), b.at(point)
+			return color.New(combine(x.R, y.R), combine(x.G, y.G), combine(x.B, y.B))
 		},
 	}
 }
 
-// returns a ring pattern with two colors
-func newRingPattern(a, b color.Color) *Pattern {
+// returns a test pattern
+func newTestPattern() *Pattern {
 	return &Pattern{
 		transform: matrix.DefaultTransform(),
 		colorAt: func(point tuple.Tuple) color.Color {
-			comp := math.Sqrt(math.Pow(point.X, 2) + math.Pow(point.Z, 2))
-			if math.Mod(math.Floor(comp), 2) == 0 {
-				return a
-			}
-			return b
+			return color.New(point.X, point.Y, point.Z)
 		},
 	}
 }
 
-// returns a checker pattern with two colors
-func newCheckerPattern(a, b color.Color) *Pattern {
+// returns a pattern that wraps the texture around the object.
+func NewTexturePattern(texture *Texture, mapping Mapping) *Pattern {
+	return newUVPattern(texture.ColorAt, mapping)
+}
+
+// returns a checker pattern of width x height squares in the texture space, handy for checking mappings.
+func NewUVCheckerPattern(width, height float64, a, b color.Color, mapping Mapping) *Pattern {
+	return newUVPattern(func(u, v float64) color.Color {
+		if math.Mod(math.Floor(u*width)+math.Floor(v*height), 2) == 0 {
+			return a
+		}
+		return b
+	}, mapping)
+}
+
+func newUVPattern(uvAt func(u, v float64) color.Color, mapping Mapping) *Pattern {
 	return &Pattern{
 		transform: matrix.DefaultTransform(),
 		colorAt: func(point tuple.Tuple) color.Color {
-			sum := math.Floor(point.X) + math.Floor(point.Y) + math.Floor(point.Z)
-			if math.Mod(sum, 2) == 0 {
-				return a
-			}
-			return b
+			return uvAt(mapping.UV(point))
 		},
+		uvAt:    uvAt,
+		mapping: mapping,
 	}
 }
 
-// returns a test pattern
-func newTestPattern() *Pattern {
+// NoiseKind is the look of a noise pattern.
+type NoiseKind int
+
+const (
+	Clouds    NoiseKind = iota // soft blobs of the two colors.
+	Turbulent                  // sharp creases of the first color over the second.
+	Marble                     // bands along x, bent by turbulence.
+	Wood                       // rings around the y axis, distorted by the noise.
+)
+
+// returns a pattern that blends two colors with the noise.
+func NewNoisePattern(kind NoiseKind, noise *Noise, a, b color.Color) *Pattern {
+	var blend func(tuple.Tuple) float64
+	switch kind {
+	case Clouds:
+		blend = func(p tuple.Tuple) float64 { return noise.FBM(p)*0.5 + 0.5 }
+	case Turbulent:
+		blend = noise.Turbulence
+	case Marble:
+		blend = func(p tuple.Tuple) float64 {
+			return 0.5 + 0.5*math.Sin((p.X+4*noise.Turbulence(p))*math.Pi)
+		}
+	case Wood:
+		blend = func(p tuple.Tuple) float64 {
+			return fraction(math.Sqrt(p.X*p.X+p.Z*p.Z) + 0.5*noise.FBM(p))
+		}
+	default:
+		panic(fmt.Errorf("not supported noise pattern: %d", kind))
+	}
+
 	return &Pattern{
 		transform: matrix.DefaultTransform(),
 		colorAt: func(point tuple.Tuple) color.Color {
-			return color.New(point.X, point.Y, point.Z)
+			return mix(a, b, blend(point))
 		},
 	}
 }
+
+// returns the pattern with its input jittered by the noise, the stripes of a stripe pattern get wavy.
+// Amount is the largest distance a point is moved. Flat patterns have their texture coordinates jittered.
+func NewPerturbedPattern(pattern *Pattern, noise *Noise, amount float64) *Pattern {
+	// the offsets decorrelate the noise along the axes.
+	jitter := func(p tuple.Tuple, offset float64) float64 {
+		return amount * noise.FBM(tuple.NewPoint(p.X+offset, p.Y+offset, p.Z+offset))
+	}
+
+	perturbed := &Pattern{
+		transform: matrix.DefaultTransform(),
+		colorAt: func(p tuple.Tuple) color.Color {
+			return pattern.at(tuple.NewPoint(p.X+jitter(p, 0), p.Y+jitter(p, 31.4), p.Z+jitter(p, 57.9)))
+		},
+		mapping: pattern.mapping,
+	}
+	if pattern.UVMapped() {
+		perturbed.uvAt = func(u, v float64) color.Color {
+			p := tuple.NewPoint(u, v, 0)
+			return pattern.uvAt(u+jitter(p, 0), v+jitter(p, 31.4))
+		}
+	}
+	return perturbed
+}
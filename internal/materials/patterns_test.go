@@ -1,9 +1,11 @@
 package materials
 
 import (
+	"math"
 	"testing"
 
 	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/matrix"
 	"github.com/kaizencodes/glimpse/internal/tuple"
 )
 
@@ -213,3 +215,54 @@ func TestTestPattern(t *testing.T) {
 		}
 	}
 }
+
+func TestNestedPattern(t *testing.T) {
+	// A checker of stripes, the second stripes are rotated to run along z and scaled down
+	red, green, blue := color.New(1, 0, 0), color.New(0, 1, 0), color.New(0, 0, 1)
+	along := newStripePattern(red, color.White())
+	across := newStripePattern(green, blue)
+	across.SetTransform(matrix.Multiply(matrix.RotationY(math.Pi/2), matrix.Scaling(0.5, 0.5, 0.5)))
+	pattern := NewNestedPattern(Checker, along, across)
+
+	var tests = []struct {
+		point    tuple.Tuple
+		expected color.Color
+	}{
+		{point: tuple.NewPoint(0.5, 0, 0.5), expected: red},
+		{point: tuple.NewPoint(0.5, 0, 2.5), expected: red},
+		{point: tuple.NewPoint(0.5, 0, -0.25), expected: green},
+		{point: tuple.NewPoint(0.5, 0, -0.75), expected: blue},
+	}
+
+	for _, test := range tests {
+		if result := pattern.colorAt(test.point); !test.expected.Equal(result) {
+			t.Errorf("ColorAt:%s, result: \n%s. \nexpected: \n%s", test.point, result, test.expected)
+		}
+	}
+}
+
+func TestBlendAndMaskPatterns(t *testing.T) {
+	red, blue := newBasePattern(color.New(1, 0, 0)), newBasePattern(color.New(0, 0, 1))
+	gradientMask := newGradientPattern(color.Black(), color.White())
+	point := tuple.NewPoint(0.25, 0, 0)
+
+	var tests = []struct {
+		name     string
+		pattern  *Pattern
+		expected color.Color
+	}{
+		{name: "blend", pattern: NewBlendPattern(red, blue, 0.25), expected: color.New(0.75, 0, 0.25)},
+		{name: "mask", pattern: NewMaskPattern(red, blue, gradientMask), expected: color.New(0.75, 0, 0.25)},
+		{name: "add", pattern: NewOperatorPattern(Add, red, blue), expected: color.New(1, 0, 1)},
+		{name: "subtract", pattern: NewOperatorPattern(Subtract, red, blue), expected: color.New(1, 0, -1)},
+		{name: "multiply", pattern: NewOperatorPattern(Multiply, red, gradientMask), expected: color.New(0.25, 0, 0)},
+		{name: "min", pattern: NewOperatorPattern(Min, red, gradientMask), expected: color.New(0.25, 0, 0)},
+		{name: "max", pattern: NewOperatorPattern(Max, red, gradientMask), expected: color.New(1, 0.25, 0.25)},
+	}
+
+	for _, test := range tests {
+		if result := test.pattern.colorAt(point); !test.expected.Equal(result) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
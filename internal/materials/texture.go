@@ -0,0 +1,186 @@
+package materials
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+// Filter decides how a texture is sampled between its pixels.
+type Filter int
+
+const (
+	NearestFilter  Filter = iota // the closest pixel, keeps the texture blocky.
+	BilinearFilter               // blends the four closest pixels.
+)
+
+// Wrap decides what happens outside of the 0 to 1 texture coordinates.
+type Wrap int
+
+const (
+	RepeatWrap Wrap = iota // the texture tiles.
+	ClampWrap              // the edge pixels are stretched.
+)
+
+// Texture is an image that is sampled with texture coordinates.
+type Texture struct {
+	Image  canvas.Canvas
+	Filter Filter
+	Wrap   Wrap
+}
+
+func NewTexture(image canvas.Canvas, filter Filter, wrap Wrap) *Texture {
+	return &Texture{Image: image, Filter: filter, Wrap: wrap}
+}
+
+// ColorAt returns the color of the texture at the texture coordinates.
+// (0, 0) is the bottom left corner of the image, (1, 1) is the top right.
+func (t *Texture) ColorAt(u, v float64) color.Color {
+	width, height := len(t.Image), len(t.Image[0])
+	// the pixel coordinates, pixel centers are at half units.
+	x := u * float64(width)
+	y := (1 - v) * float64(height)
+
+	if t.Filter == NearestFilter {
+		return t.pixel(int(math.Floor(x)), int(math.Floor(y)))
+	}
+
+	x, y = x-0.5, y-0.5
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+	top := mix(t.pixel(int(x0), int(y0)), t.pixel(int(x0)+1, int(y0)), fx)
+	bottom := mix(t.pixel(int(x0), int(y0)+1), t.pixel(int(x0)+1, int(y0)+1), fx)
+	return mix(top, bottom, fy)
+}
+
+// returns the pixel, the coordinates outside of the image are wrapped or clamped.
+func (t *Texture) pixel(x, y int) color.Color {
+	width, height := len(t.Image), len(t.Image[0])
+	if t.Wrap == ClampWrap {
+		x = min(max(x, 0), width-1)
+		y = min(max(y, 0), height-1)
+	} else {
+		x = ((x % width) + width) % width
+		y = ((y % height) + height) % height
+	}
+	return t.Image[x][y]
+}
+
+func mix(a, b color.Color, t float64) color.Color {
+	return color.Add(a.Scalar(1-t), b.Scalar(t))
+}
+
+// ReadImage reads a PNG or a PPM (P3 or P6) image, the channels are scaled to 0-1.
+// With srgb the colors are converted from sRGB to linear, which is how most images are stored.
+func ReadImage(r io.Reader, srgb bool) (canvas.Canvas, error) {
+	buf := bufio.NewReader(r)
+	magic, err := buf.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	var c canvas.Canvas
+	if bytes.Equal(magic, []byte("P3")) || bytes.Equal(magic, []byte("P6")) {
+		c, err = readPPM(buf)
+	} else {
+		c, err = readPNG(buf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if srgb {
+		for x := range c {
+			for y := range c[x] {
+				c[x][y] = color.New(decodeSRGB(c[x][y].R), decodeSRGB(c[x][y].G), decodeSRGB(c[x][y].B))
+			}
+		}
+	}
+	return c, nil
+}
+
+func readPNG(r io.Reader) (canvas.Canvas, error) {
+	img, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	c := canvas.New(bounds.Dx(), bounds.Dy())
+	for x := 0; x < bounds.Dx(); x++ {
+		for y := 0; y < bounds.Dy(); y++ {
+			c[x][y] = fromImageColor(img, bounds.Min.X+x, bounds.Min.Y+y)
+		}
+	}
+	return c, nil
+}
+
+func fromImageColor(img image.Image, x, y int) color.Color {
+	// 16 bit channels with premultiplied alpha, the alpha is ignored.
+	r, g, b, a := img.At(x, y).RGBA()
+	if a == 0 {
+		return color.Black()
+	}
+	return color.New(float64(r)/float64(a), float64(g)/float64(a), float64(b)/float64(a))
+}
+
+func readPPM(r *bufio.Reader) (canvas.Canvas, error) {
+	var magic string
+	var width, height, maxValue int
+	if _, err := fmt.Fscan(r, &magic, &width, &height, &maxValue); err != nil {
+		return nil, fmt.Errorf("invalid ppm header: %w", err)
+	}
+	if width <= 0 || height <= 0 || maxValue <= 0 || maxValue > 255 {
+		return nil, fmt.Errorf("unsupported ppm: %dx%d, max value %d", width, height, maxValue)
+	}
+
+	var read func() (int, error)
+	if magic == "P6" {
+		// a single whitespace separates the header from the binary data.
+		if _, err := r.ReadByte(); err != nil {
+			return nil, err
+		}
+		read = func() (int, error) {
+			b, err := r.ReadByte()
+			return int(b), err
+		}
+	} else {
+		read = func() (int, error) {
+			var value int
+			_, err := fmt.Fscan(r, &value)
+			return value, err
+		}
+	}
+
+	c := canvas.New(width, height)
+	scale := float64(maxValue)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var channels [3]int
+			for i := range channels {
+				value, err := read()
+				if err != nil {
+					return nil, fmt.Errorf("invalid ppm data: %w", err)
+				}
+				channels[i] = value
+			}
+			c[x][y] = color.New(float64(channels[0])/scale, float64(channels[1])/scale, float64(channels[2])/scale)
+		}
+	}
+	return c, nil
+}
+
+// the inverse of the sRGB transfer function.
+func decodeSRGB(x float64) float64 {
+	if x <= 0.04045 {
+		return x / 12.92
+	}
+	return math.Pow((x+0.055)/1.055, 2.4)
+}
@@ -0,0 +1,97 @@
+package materials
+
+import (
+	"bytes"
+	"image"
+	imagecolor "image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+// A 2x2 image, black and white on top, red and green at the bottom.
+func testImage() canvas.Canvas {
+	c := canvas.New(2, 2)
+	c[0][0] = color.Black()
+	c[1][0] = color.White()
+	c[0][1] = color.New(1, 0, 0)
+	c[1][1] = color.New(0, 1, 0)
+	return c
+}
+
+func TestTextureColorAt(t *testing.T) {
+	var tests = []struct {
+		name     string
+		texture  *Texture
+		u, v     float64
+		expected color.Color
+	}{
+		{name: "nearest top left", texture: NewTexture(testImage(), NearestFilter, RepeatWrap), u: 0.1, v: 0.9, expected: color.Black()},
+		{name: "nearest bottom right", texture: NewTexture(testImage(), NearestFilter, RepeatWrap), u: 0.9, v: 0.1, expected: color.New(0, 1, 0)},
+		{name: "nearest repeats", texture: NewTexture(testImage(), NearestFilter, RepeatWrap), u: 1.6, v: -0.4, expected: color.White()},
+		{name: "nearest clamps", texture: NewTexture(testImage(), NearestFilter, ClampWrap), u: 1.6, v: -0.4, expected: color.New(0, 1, 0)},
+		{name: "bilinear at a pixel center", texture: NewTexture(testImage(), BilinearFilter, ClampWrap), u: 0.25, v: 0.75, expected: color.Black()},
+		{name: "bilinear blends", texture: NewTexture(testImage(), BilinearFilter, ClampWrap), u: 0.5, v: 0.5, expected: color.New(0.5, 0.5, 0.25)},
+		{name: "bilinear clamps at the edge", texture: NewTexture(testImage(), BilinearFilter, ClampWrap), u: 0, v: 1, expected: color.Black()},
+		{name: "bilinear repeats at the edge", texture: NewTexture(testImage(), BilinearFilter, RepeatWrap), u: 0, v: 0.75, expected: color.New(0.5, 0.5, 0.5)},
+	}
+
+	for _, test := range tests {
+		if result := test.texture.ColorAt(test.u, test.v); !result.Equal(test.expected) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestReadImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	img.SetNRGBA(1, 0, imagecolor.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	img.SetNRGBA(0, 1, imagecolor.NRGBA{R: 255, A: 255})
+	img.SetNRGBA(1, 1, imagecolor.NRGBA{G: 255, A: 255})
+	img.SetNRGBA(0, 0, imagecolor.NRGBA{A: 255})
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, img); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name  string
+		input string
+	}{
+		{name: "png", input: pngData.String()},
+		{name: "p3", input: "P3\n2 2\n255\n0 0 0 255 255 255\n255 0 0 0 255 0\n"},
+		{name: "p6", input: "P6\n2 2\n255\n" + string([]byte{0, 0, 0, 255, 255, 255, 255, 0, 0, 0, 255, 0})},
+	}
+
+	for _, test := range tests {
+		result, err := ReadImage(strings.NewReader(test.input), false)
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", test.name, err)
+			continue
+		}
+		expected := testImage()
+		for x := range expected {
+			for y := range expected[x] {
+				if !result[x][y].Equal(expected[x][y]) {
+					t.Errorf("%s: expected %s at (%d, %d), got %s", test.name, expected[x][y], x, y, result[x][y])
+				}
+			}
+		}
+	}
+
+	// sRGB images are converted to linear colors
+	result, err := ReadImage(strings.NewReader("P3\n1 1\n255\n188 255 0\n"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := color.New(0.5028864580325687, 1, 0); !result[0][0].Equal(expected) {
+		t.Errorf("srgb: expected %s, got %s", expected, result[0][0])
+	}
+
+	if _, err := ReadImage(strings.NewReader("P3\n2 2\n255\n0 0 0\n"), false); err == nil {
+		t.Errorf("expected an error for truncated data")
+	}
+}
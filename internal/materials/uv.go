@@ -0,0 +1,98 @@
+package materials
+
+import (
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Mapping wraps a flat (u, v) pattern around an object, u and v are between 0 and 1.
+// v grows upwards, (0, 0) is the bottom left corner of an image.
+type Mapping int
+
+const (
+	AutoMapping        Mapping = iota // picked by the shape, e.g. spherical for spheres.
+	SphericalMapping                  // latitude and longitude, like a map of the earth.
+	PlanarMapping                     // the xz plane, repeated every unit.
+	CylindricalMapping                // around the y axis, repeated every unit of height.
+	CubeMapping                       // the faces of the cube, the image is laid out as a horizontal cross.
+	VertexMapping                     // the texture coordinates of the vertices, for triangles of models.
+)
+
+// UV returns the texture coordinates of a point. The shape specific mappings fall back to planar.
+func (m Mapping) UV(p tuple.Tuple) (float64, float64) {
+	switch m {
+	case SphericalMapping:
+		return sphericalUV(p)
+	case CylindricalMapping:
+		return cylindricalUV(p)
+	case CubeMapping:
+		return cubeUV(p)
+	default:
+		return planarUV(p)
+	}
+}
+
+func sphericalUV(p tuple.Tuple) (float64, float64) {
+	// the azimuthal angle, from -π to π around the y axis.
+	theta := math.Atan2(p.X, p.Z)
+	radius := tuple.NewVector(p.X, p.Y, p.Z).Magnitude()
+	// the polar angle, from 0 at the north pole to π at the south pole.
+	phi := math.Acos(p.Y / radius)
+
+	u := 1 - (theta/(2*math.Pi) + 0.5)
+	v := 1 - phi/math.Pi
+	return u, v
+}
+
+func planarUV(p tuple.Tuple) (float64, float64) {
+	return fraction(p.X), fraction(p.Z)
+}
+
+func cylindricalUV(p tuple.Tuple) (float64, float64) {
+	theta := math.Atan2(p.X, p.Z)
+	u := 1 - (theta/(2*math.Pi) + 0.5)
+	return u, fraction(p.Y)
+}
+
+// The faces are laid out as a cross, 4 faces wide and 3 faces high:
+//
+//	        up
+//	left  front  right  back
+//	       down
+func cubeUV(p tuple.Tuple) (float64, float64) {
+	var u, v float64
+	var column, row float64
+
+	abs := math.Max(math.Max(math.Abs(p.X), math.Abs(p.Y)), math.Abs(p.Z))
+	switch abs {
+	case p.X: // right
+		u, v = (1-p.Z)/2, (p.Y+1)/2
+		column, row = 2, 1
+	case -p.X: // left
+		u, v = (p.Z+1)/2, (p.Y+1)/2
+		column, row = 0, 1
+	case p.Y: // up
+		u, v = (p.X+1)/2, (1-p.Z)/2
+		column, row = 1, 2
+	case -p.Y: // down
+		u, v = (p.X+1)/2, (p.Z+1)/2
+		column, row = 1, 0
+	case p.Z: // front
+		u, v = (p.X+1)/2, (p.Y+1)/2
+		column, row = 1, 1
+	default: // back
+		u, v = (1-p.X)/2, (p.Y+1)/2
+		column, row = 3, 1
+	}
+
+	return (column + clamp(u)) / 4, (row + clamp(v)) / 3
+}
+
+func fraction(x float64) float64 {
+	return x - math.Floor(x)
+}
+
+func clamp(x float64) float64 {
+	return math.Min(math.Max(x, 0), 1)
+}
@@ -0,0 +1,53 @@
+package materials
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestMappingUV(t *testing.T) {
+	var tests = []struct {
+		mapping              Mapping
+		point                tuple.Tuple
+		expectedU, expectedV float64
+	}{
+		{mapping: SphericalMapping, point: tuple.NewPoint(0, 0, -1), expectedU: 0, expectedV: 0.5},
+		{mapping: SphericalMapping, point: tuple.NewPoint(1, 0, 0), expectedU: 0.25, expectedV: 0.5},
+		{mapping: SphericalMapping, point: tuple.NewPoint(0, 0, 1), expectedU: 0.5, expectedV: 0.5},
+		{mapping: SphericalMapping, point: tuple.NewPoint(-1, 0, 0), expectedU: 0.75, expectedV: 0.5},
+		{mapping: SphericalMapping, point: tuple.NewPoint(0, 1, 0), expectedU: 0.5, expectedV: 1},
+		{mapping: SphericalMapping, point: tuple.NewPoint(0, -1, 0), expectedU: 0.5, expectedV: 0},
+		{mapping: SphericalMapping, point: tuple.NewPoint(math.Sqrt(2)/2, math.Sqrt(2)/2, 0), expectedU: 0.25, expectedV: 0.75},
+
+		{mapping: PlanarMapping, point: tuple.NewPoint(0.25, 0, 0.5), expectedU: 0.25, expectedV: 0.5},
+		{mapping: PlanarMapping, point: tuple.NewPoint(0.25, 0.5, -0.25), expectedU: 0.25, expectedV: 0.75},
+		{mapping: PlanarMapping, point: tuple.NewPoint(1.25, 0, 0.5), expectedU: 0.25, expectedV: 0.5},
+		{mapping: PlanarMapping, point: tuple.NewPoint(0.25, 0, -1.75), expectedU: 0.25, expectedV: 0.25},
+		{mapping: PlanarMapping, point: tuple.NewPoint(1, 0, -1), expectedU: 0, expectedV: 0},
+
+		{mapping: CylindricalMapping, point: tuple.NewPoint(0, 0, -1), expectedU: 0, expectedV: 0},
+		{mapping: CylindricalMapping, point: tuple.NewPoint(0, 0.5, -1), expectedU: 0, expectedV: 0.5},
+		{mapping: CylindricalMapping, point: tuple.NewPoint(0, 1, -1), expectedU: 0, expectedV: 0},
+		{mapping: CylindricalMapping, point: tuple.NewPoint(1, 0.5, 0), expectedU: 0.25, expectedV: 0.5},
+		{mapping: CylindricalMapping, point: tuple.NewPoint(0, -0.25, 1), expectedU: 0.5, expectedV: 0.75},
+		{mapping: CylindricalMapping, point: tuple.NewPoint(-1, 1.25, 0), expectedU: 0.75, expectedV: 0.25},
+
+		// the faces of the cube are laid out as a cross
+		{mapping: CubeMapping, point: tuple.NewPoint(-0.5, 0.5, 1), expectedU: 1.25 / 4, expectedV: 1.75 / 3},
+		{mapping: CubeMapping, point: tuple.NewPoint(0.5, -0.5, -1), expectedU: 3.25 / 4, expectedV: 1.25 / 3},
+		{mapping: CubeMapping, point: tuple.NewPoint(1, 0.5, -0.5), expectedU: 2.75 / 4, expectedV: 1.75 / 3},
+		{mapping: CubeMapping, point: tuple.NewPoint(-1, 0.5, -0.5), expectedU: 0.25 / 4, expectedV: 1.75 / 3},
+		{mapping: CubeMapping, point: tuple.NewPoint(-0.5, 1, -0.5), expectedU: 1.25 / 4, expectedV: 2.75 / 3},
+		{mapping: CubeMapping, point: tuple.NewPoint(-0.5, -1, 0.5), expectedU: 1.25 / 4, expectedV: 0.75 / 3},
+	}
+
+	for _, test := range tests {
+		u, v := test.mapping.UV(test.point)
+		if !utils.FloatEquals(u, test.expectedU) || !utils.FloatEquals(v, test.expectedV) {
+			t.Errorf("mapping %d of %s: expected (%f, %f), got (%f, %f)", test.mapping, test.point, test.expectedU, test.expectedV, u, v)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Post-processing turns the linear colors of a render into colors ready for display.
+// It sits between the renderer and the exporters of low dynamic range formats.
+package postprocess
+
+import (
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+// Pipeline adjusts the exposure, compresses the bright colors with a tone mapper
+// and encodes the result for sRGB displays, in this order.
+type Pipeline struct {
+	Exposure   float64    // in stops, every stop doubles the brightness.
+	ToneMapper ToneMapper // nil leaves the colors as they are, the exporters clip them.
+	SRGB       bool       // encode the colors with the sRGB transfer function.
+}
+
+// Apply returns the processed copy of the canvas.
+func (p Pipeline) Apply(c canvas.Canvas) canvas.Canvas {
+	result := canvas.New(len(c), len(c[0]))
+	for x := 0; x < len(c); x++ {
+		for y := 0; y < len(c[x]); y++ {
+			result[x][y] = p.Color(c[x][y])
+		}
+	}
+	return result
+}
+
+// Color processes a single color.
+func (p Pipeline) Color(c color.Color) color.Color {
+	if p.Exposure != 0 {
+		c = c.Scalar(math.Exp2(p.Exposure))
+	}
+	if p.ToneMapper != nil {
+		c = p.ToneMapper.Map(c)
+	}
+	if p.SRGB {
+		c = color.New(EncodeSRGB(c.R), EncodeSRGB(c.G), EncodeSRGB(c.B))
+	}
+	return c
+}
+
+// EncodeSRGB applies the sRGB transfer function to a linear value.
+// Displays expect it, without it the midtones look too dark.
+func EncodeSRGB(v float64) float64 {
+	v = math.Min(math.Max(v, 0), 1)
+	if v <= 0.0031308 {
+		return 12.92 * v
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
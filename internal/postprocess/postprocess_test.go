@@ -0,0 +1,73 @@
+package postprocess
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestEncodeSRGB(t *testing.T) {
+	var tests = []struct {
+		v, expected float64
+	}{
+		{v: -1, expected: 0},
+		{v: 0, expected: 0},
+		{v: 0.002, expected: 0.02584},
+		{v: 0.18, expected: 0.4613561295004961},
+		{v: 1, expected: 1},
+		{v: 4, expected: 1},
+	}
+
+	for _, test := range tests {
+		if result := EncodeSRGB(test.v); !utils.FloatEquals(result, test.expected) {
+			t.Errorf("EncodeSRGB(%f) expected %f, got %f", test.v, test.expected, result)
+		}
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	var tests = []struct {
+		pipeline Pipeline
+		input    color.Color
+		expected color.Color
+	}{
+		{
+			// The zero pipeline leaves the color alone
+			pipeline: Pipeline{},
+			input:    color.New(2, 0.5, -1),
+			expected: color.New(2, 0.5, -1),
+		},
+		{
+			// Every stop doubles the brightness
+			pipeline: Pipeline{Exposure: 2},
+			input:    color.New(0.1, 0.2, 0),
+			expected: color.New(0.4, 0.8, 0),
+		},
+		{
+			pipeline: Pipeline{Exposure: -1, ToneMapper: Reinhard{}},
+			input:    color.New(2, 0.5, 0),
+			expected: color.New(0.5, 0.2, 0),
+		},
+		{
+			pipeline: Pipeline{ToneMapper: Reinhard{}, SRGB: true},
+			input:    color.New(1, 0, 0),
+			expected: color.New(0.7353569830524495, 0, 0),
+		},
+	}
+
+	for _, test := range tests {
+		if result := test.pipeline.Color(test.input); !result.Equal(test.expected) {
+			t.Errorf("%v.Color(%s) expected %s, got %s", test.pipeline, test.input, test.expected, result)
+		}
+	}
+
+	// Apply processes every pixel into a new canvas
+	c := canvas.New(2, 1)
+	c[1][0] = color.New(1, 1, 1)
+	result := Pipeline{Exposure: 1}.Apply(c)
+	if !result[1][0].Equal(color.New(2, 2, 2)) || !c[1][0].Equal(color.New(1, 1, 1)) {
+		t.Errorf("Apply expected a processed copy, got %s from %s", result[1][0], c[1][0])
+	}
+}
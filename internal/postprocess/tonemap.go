@@ -0,0 +1,79 @@
+package postprocess
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+// ToneMapper compresses the unbounded colors of a render into the 0-1 range,
+// so the bright parts roll off smoothly instead of clipping.
+type ToneMapper interface {
+	Map(c color.Color) color.Color
+}
+
+// Reinhard maps every channel with x / (1 + x), it never reaches white.
+type Reinhard struct{}
+
+func (t Reinhard) Map(c color.Color) color.Color {
+	return perChannel(c, func(x float64) float64 {
+		return x / (1 + x)
+	})
+}
+
+// ACES is Krzysztof Narkowicz's fit of the ACES filmic curve, it has a slight toe and a soft shoulder.
+type ACES struct{}
+
+func (t ACES) Map(c color.Color) color.Color {
+	return perChannel(c, func(x float64) float64 {
+		return math.Min(math.Max((x*(2.51*x+0.03))/(x*(2.43*x+0.59)+0.14), 0), 1)
+	})
+}
+
+// the white point of Uncharted2 from Hable's talk.
+const defaultWhite = 11.2
+
+// Uncharted2 is John Hable's filmic curve from Uncharted 2.
+// Colors at or above White map to white, a White of 0 or below uses the default of 11.2.
+type Uncharted2 struct {
+	White float64
+}
+
+func (t Uncharted2) Map(c color.Color) color.Color {
+	w := t.White
+	if w <= 0 {
+		// hable(0) is 0, the division would turn every pixel into NaN or Inf.
+		w = defaultWhite
+	}
+	// the curve is tuned for an exposure bias of 2.
+	white := hable(w)
+	return perChannel(c, func(x float64) float64 {
+		return math.Min(hable(2*x)/white, 1)
+	})
+}
+
+func hable(x float64) float64 {
+	const a, b, c, d, e, f = 0.15, 0.50, 0.10, 0.20, 0.02, 0.30
+	return (x*(a*x+c*b)+d*e)/(x*(a*x+b)+d*f) - e/f
+}
+
+func perChannel(c color.Color, f func(float64) float64) color.Color {
+	return color.New(f(math.Max(c.R, 0)), f(math.Max(c.G, 0)), f(math.Max(c.B, 0)))
+}
+
+// ParseToneMapper converts the name of a tone mapper into a ToneMapper, "none" returns nil.
+func ParseToneMapper(name string) (ToneMapper, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "reinhard":
+		return Reinhard{}, nil
+	case "aces":
+		return ACES{}, nil
+	case "uncharted2":
+		return Uncharted2{White: defaultWhite}, nil
+	default:
+		return nil, fmt.Errorf("unknown tone mapper: %s", name)
+	}
+}
@@ -0,0 +1,69 @@
+package postprocess
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+func TestToneMappers(t *testing.T) {
+	mappers := []ToneMapper{Reinhard{}, ACES{}, Uncharted2{White: 11.2}}
+
+	for _, mapper := range mappers {
+		// Black stays black
+		if result := mapper.Map(color.Black()); !result.Equal(color.Black()) {
+			t.Errorf("%T maps black to %s", mapper, result)
+		}
+
+		// The curves are increasing and stay in range, even for very bright colors
+		previous := -1.0
+		for _, v := range []float64{0.01, 0.1, 0.5, 1, 2, 10, 1000} {
+			result := mapper.Map(color.New(v, v, v))
+			if result.R <= previous && result.R < 1 {
+				t.Errorf("%T is not increasing at %f, got %f after %f", mapper, v, result.R, previous)
+			}
+			if result.R < 0 || result.R > 1 {
+				t.Errorf("%T maps %f out of range to %f", mapper, v, result.R)
+			}
+			previous = result.R
+		}
+	}
+
+	// Uncharted2 reaches white at the white point
+	if result := (Uncharted2{White: 11.2}).Map(color.New(5.6, 5.6, 5.6)); !result.Equal(color.White()) {
+		t.Errorf("Uncharted2 expected white at the white point, got %s", result)
+	}
+
+	// Without a white point the default is used instead of dividing by 0
+	for _, white := range []float64{0, -1} {
+		input := color.New(0.5, 1, 2)
+		if result, expected := (Uncharted2{White: white}).Map(input), (Uncharted2{White: 11.2}).Map(input); !result.Equal(expected) {
+			t.Errorf("Uncharted2 with white %f expected %s, got %s", white, expected, result)
+		}
+	}
+}
+
+func TestParseToneMapper(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected ToneMapper
+		err      bool
+	}{
+		{name: "", expected: nil},
+		{name: "none", expected: nil},
+		{name: "reinhard", expected: Reinhard{}},
+		{name: "aces", expected: ACES{}},
+		{name: "uncharted2", expected: Uncharted2{White: 11.2}},
+		{name: "filmic", err: true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseToneMapper(test.name)
+		if test.err && err == nil {
+			t.Errorf("ParseToneMapper(%s) expected an error", test.name)
+		}
+		if !test.err && result != test.expected {
+			t.Errorf("ParseToneMapper(%s) expected %v, got %v", test.name, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package renderer
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+)
+
+// Adaptive controls adaptive sampling. Every pixel starts with MinSamples rays and keeps getting
+// more, MinSamples at a time, until the standard error of its brightness drops below Threshold
+// or it reaches MaxSamples. Flat areas stop early, while edges and noisy areas get refined.
+// Adaptive sampling is disabled when MaxSamples is 0.
+type Adaptive struct {
+	MinSamples, MaxSamples int
+	Threshold              float64
+}
+
+func (a Adaptive) enabled() bool {
+	return a.MaxSamples > 0
+}
+
+// Computes the color of the pixel (x, y) with adaptive sampling.
+func sampleAdaptive(c *camera.Camera, w *scenes.Scene, x, y int, opts Options) pixel {
+	filter := pixelFilter(opts)
+	radius := filter.Radius()
+	// at least 2 samples are needed to estimate the variance.
+	batch := max(opts.Adaptive.MinSamples, 2)
+	limit := max(opts.Adaptive.MaxSamples, batch)
+
+	acc := newAccumulator(opts)
+	var stats variance
+	for tried := 0; tried < limit; {
+		for i := 0; i < batch && tried < limit; i++ {
+			tried++
+			dx, dy := (2*rand.Float64()-1)*radius, (2*rand.Float64()-1)*radius
+			if col, ok := acc.add(c, w, x, y, dx, dy, filter); ok {
+				stats.add(col.Luminance())
+			}
+		}
+
+		if stats.n >= 2 && stats.standardError() <= opts.Adaptive.Threshold {
+			break
+		}
+	}
+
+	return acc.pixel(c, w, x, y)
+}
+
+// variance is computed incrementally with Welford's algorithm.
+type variance struct {
+	n        int
+	mean, m2 float64
+}
+
+func (v *variance) add(value float64) {
+	v.n++
+	delta := value - v.mean
+	v.mean += delta / float64(v.n)
+	v.m2 += delta * (value - v.mean)
+}
+
+// the standard error of the mean, it shrinks as more samples are added.
+func (v *variance) standardError() float64 {
+	if v.n < 2 {
+		return math.Inf(1)
+	}
+	return math.Sqrt(v.m2 / float64(v.n-1) / float64(v.n))
+}
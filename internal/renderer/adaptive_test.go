@@ -0,0 +1,73 @@
+package renderer
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestVariance(t *testing.T) {
+	var v variance
+	if !math.IsInf(v.standardError(), 1) {
+		t.Errorf("variance of no samples expected to be infinite, got %f", v.standardError())
+	}
+
+	for _, value := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		v.add(value)
+	}
+	if !utils.FloatEquals(v.mean, 5) {
+		t.Errorf("variance expected mean 5, got %f", v.mean)
+	}
+	// sample variance is 32/7, the standard error is sqrt(32/7/8)
+	if expected := math.Sqrt(32.0 / 7.0 / 8.0); !utils.FloatEquals(v.standardError(), expected) {
+		t.Errorf("variance expected standard error %f, got %f", expected, v.standardError())
+	}
+}
+
+func TestSampleAdaptive(t *testing.T) {
+	scene := scenes.Default()
+	for _, shape := range scene.Shapes {
+		mat := shape.Material()
+		mat.Ambient, mat.Diffuse, mat.Specular = 1, 0, 0
+	}
+	c := camera.New(11, 11, math.Pi/2)
+	c.SetTransform(camera.ViewTransformation(
+		tuple.NewPoint(0, 0, -5),
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewVector(0, 1, 0),
+	))
+	// the batches are large enough that the first one practically always straddles the edge.
+	opts := Options{Adaptive: Adaptive{MinSamples: 32, MaxSamples: 128, Threshold: 0.01}}
+
+	// A flat pixel stops after the first batch
+	flat := sampleAdaptive(c, scene, 5, 5, opts)
+	if flat.samples != 32 {
+		t.Errorf("sampleAdaptive expected 32 samples for a flat pixel, got %d", flat.samples)
+	}
+	if expected := samplePixel(c, scene, 5, 5, Options{Samples: 1}).color; !flat.color.Equal(expected) {
+		t.Errorf("sampleAdaptive expected %s, got %s", expected, flat.color)
+	}
+
+	// A pixel on the edge of the sphere keeps refining
+	edge := sampleAdaptive(c, scene, 6, 5, opts)
+	if edge.samples <= 32 {
+		t.Errorf("sampleAdaptive expected more than 32 samples for an edge pixel, got %d", edge.samples)
+	}
+	if edge.samples > 128 {
+		t.Errorf("sampleAdaptive expected at most 128 samples, got %d", edge.samples)
+	}
+	if edge.color.G <= 0 || edge.color.G >= flat.color.G {
+		t.Errorf("sampleAdaptive expected an anti-aliased edge, got %s", edge.color)
+	}
+
+	// The sample counts are recorded in the frame
+	frame, _ := RenderFrame(context.Background(), c, scene, opts)
+	if frame.Samples[0][0] != 32 || frame.Samples[6][5] <= 32 {
+		t.Errorf("RenderFrame expected adaptive sample counts, got %d and %d", frame.Samples[0][0], frame.Samples[6][5])
+	}
+}
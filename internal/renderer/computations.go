@@ -2,7 +2,9 @@ package renderer
 
 import (
 	"math"
+	"math/rand/v2"
 
+	"github.com/kaizencodes/glimpse/internal/color"
 	"github.com/kaizencodes/glimpse/internal/ray"
 	"github.com/kaizencodes/glimpse/internal/shapes"
 	"github.com/kaizencodes/glimpse/internal/tuple"
@@ -49,6 +51,30 @@ func (comps Computations) schlick() float64 {
 	return r0 + (1-r0)*math.Pow(1-cos, 5)
 }
 
+// The share of the light arriving from the mirror direction that the surface reflects, it depends on the BSDF.
+func (comps Computations) reflectance() color.Color {
+	mat := comps.Shape.Material()
+	return mat.BSDF().Reflectance(mat, shapes.ColorAt(comps.Point, comps.Shape), comps.NormalV, comps.EyeV)
+}
+
+// Rough surfaces reflect and refract around a randomly tilted microfacet normal, the samples
+// of a pixel average them into a blurry reflection. Smooth surfaces keep the normal.
+func (comps Computations) microfacet() Computations {
+	m := comps.Shape.Material().BSDF().Microfacet(comps.NormalV, comps.EyeV, rand.Float64(), rand.Float64())
+	if m == comps.NormalV {
+		return comps
+	}
+
+	rough := comps
+	rough.NormalV = m
+	rough.ReflectV = tuple.Reflect(comps.EyeV.Negate(), m)
+	// a reflection into the surface falls back to the mirror direction.
+	if tuple.Dot(rough.ReflectV, comps.NormalV) <= 0 {
+		rough.ReflectV = comps.ReflectV
+	}
+	return rough
+}
+
 func prepareComputations(hit shapes.Intersection, r *ray.Ray, xs shapes.Intersections) Computations {
 	point := r.Position(hit.T())
 	normalV := shapes.NormalAt(point, hit.Shape(), hit)
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/kaizencodes/glimpse/internal/materials"
 	"github.com/kaizencodes/glimpse/internal/matrix"
 	"github.com/kaizencodes/glimpse/internal/ray"
 	"github.com/kaizencodes/glimpse/internal/shapes"
@@ -258,3 +259,32 @@ func TestRefraction(t *testing.T) {
 		}
 	}
 }
+
+func TestMicrofacet(t *testing.T) {
+	// A smooth surface keeps the mirror reflection
+	plane := shapes.NewPlane()
+	r := ray.New(tuple.NewPoint(0, 1, -1), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := shapes.NewIntersection(math.Sqrt(2), plane)
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+
+	if rough := comps.microfacet(); !rough.ReflectV.Equal(comps.ReflectV) || !rough.NormalV.Equal(comps.NormalV) {
+		t.Errorf("smooth surface: expected the mirror reflection %s, got %s", comps.ReflectV, rough.ReflectV)
+	}
+
+	// A rough surface scatters the reflection, which stays above the surface
+	plane.Material().SetBSDF(materials.GGX{Roughness: 0.6, F0: 0.04})
+	var scattered bool
+	for range 20 {
+		rough := comps.microfacet()
+		if tuple.Dot(rough.ReflectV, comps.NormalV) <= 0 {
+			t.Errorf("rough surface: expected the reflection above the surface, got %s", rough.ReflectV)
+		}
+		if !utils.FloatEquals(rough.ReflectV.Magnitude(), 1) {
+			t.Errorf("rough surface: expected a unit reflection vector, got %s", rough.ReflectV)
+		}
+		scattered = scattered || !rough.ReflectV.Equal(comps.ReflectV)
+	}
+	if !scattered {
+		t.Errorf("rough surface: expected the reflection to be scattered")
+	}
+}
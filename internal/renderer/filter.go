@@ -0,0 +1,100 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+)
+
+// Filter reconstructs the color of a pixel from the samples taken around its center.
+// Samples are spread over the square [-Radius, Radius] around the pixel center and their
+// colors are averaged using the weights the filter assigns to them.
+type Filter interface {
+	// Radius is the distance from the pixel center in pixels, beyond which the weight is zero.
+	Radius() float64
+	// Weight returns the contribution of a sample at the given offset from the pixel center.
+	Weight(dx, dy float64) float64
+}
+
+// BoxFilter weighs every sample inside the pixel equally.
+type BoxFilter struct{}
+
+func (f BoxFilter) Radius() float64 {
+	return 0.5
+}
+
+func (f BoxFilter) Weight(dx, dy float64) float64 {
+	return 1
+}
+
+// TentFilter falls off linearly from the pixel center.
+type TentFilter struct{}
+
+func (f TentFilter) Radius() float64 {
+	return 1
+}
+
+func (f TentFilter) Weight(dx, dy float64) float64 {
+	return math.Max(0, 1-math.Abs(dx)) * math.Max(0, 1-math.Abs(dy))
+}
+
+// GaussianFilter falls off along a bell curve, Alpha controls how quickly.
+// The curve is shifted down so it reaches zero at the radius.
+type GaussianFilter struct {
+	Alpha float64
+}
+
+func (f GaussianFilter) Radius() float64 {
+	return 1.5
+}
+
+func (f GaussianFilter) Weight(dx, dy float64) float64 {
+	return f.gaussian(dx) * f.gaussian(dy)
+}
+
+func (f GaussianFilter) gaussian(d float64) float64 {
+	return math.Max(0, math.Exp(-f.Alpha*d*d)-math.Exp(-f.Alpha*f.Radius()*f.Radius()))
+}
+
+// MitchellFilter is the cubic filter by Mitchell and Netravali.
+// It has small negative lobes which keep the edges sharp.
+// B = C = 1/3 is the recommended compromise between blurring and ringing.
+type MitchellFilter struct {
+	B, C float64
+}
+
+func (f MitchellFilter) Radius() float64 {
+	return 2
+}
+
+func (f MitchellFilter) Weight(dx, dy float64) float64 {
+	return f.mitchell(dx) * f.mitchell(dy)
+}
+
+func (f MitchellFilter) mitchell(d float64) float64 {
+	x := math.Abs(d)
+	b, c := f.B, f.C
+	switch {
+	case x < 1:
+		return ((12-9*b-6*c)*x*x*x + (-18+12*b+6*c)*x*x + (6 - 2*b)) / 6
+	case x < 2:
+		return ((-b-6*c)*x*x*x + (6*b+30*c)*x*x + (-12*b-48*c)*x + (8*b + 24*c)) / 6
+	default:
+		return 0
+	}
+}
+
+// ParseFilter converts the name of a filter into a Filter with its recommended parameters.
+func ParseFilter(name string) (Filter, error) {
+	switch name {
+	case "", "box":
+		return BoxFilter{}, nil
+	case "tent":
+		return TentFilter{}, nil
+	case "gaussian":
+		return GaussianFilter{Alpha: 2}, nil
+	case "mitchell":
+		return MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}, nil
+	default:
+		return nil, fmt.Errorf("unknown filter: %s", name)
+	}
+}
@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestFilterWeight(t *testing.T) {
+	var tests = []struct {
+		filter   Filter
+		dx, dy   float64
+		expected float64
+	}{
+		// The box filter weighs everything equally
+		{filter: BoxFilter{}, dx: 0, dy: 0, expected: 1},
+		{filter: BoxFilter{}, dx: 0.4, dy: -0.4, expected: 1},
+		// The tent filter falls off linearly
+		{filter: TentFilter{}, dx: 0, dy: 0, expected: 1},
+		{filter: TentFilter{}, dx: 0.5, dy: 0, expected: 0.5},
+		{filter: TentFilter{}, dx: 0.5, dy: -0.5, expected: 0.25},
+		{filter: TentFilter{}, dx: 1, dy: 0, expected: 0},
+		// The gaussian reaches zero at its radius
+		{filter: GaussianFilter{Alpha: 2}, dx: 0, dy: 0, expected: 0.9779054167276021},
+		{filter: GaussianFilter{Alpha: 2}, dx: 1.5, dy: 0, expected: 0},
+		// Mitchell-Netravali with B = C = 1/3
+		{filter: MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}, dx: 0, dy: 0, expected: 0.7901234567901234},
+		{filter: MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}, dx: 1, dy: 0, expected: 0.04938271604938271},
+		{filter: MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}, dx: 2, dy: 0, expected: 0},
+	}
+
+	for _, test := range tests {
+		if result := test.filter.Weight(test.dx, test.dy); !utils.FloatEquals(result, test.expected) {
+			t.Errorf("%T.Weight(%f, %f) expected %v, got %v", test.filter, test.dx, test.dy, test.expected, result)
+		}
+	}
+
+	// Mitchell-Netravali has negative lobes
+	if result := (MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}).Weight(1.5, 0); result >= 0 {
+		t.Errorf("MitchellFilter expected a negative lobe at 1.5, got %f", result)
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected Filter
+		err      bool
+	}{
+		{name: "", expected: BoxFilter{}},
+		{name: "box", expected: BoxFilter{}},
+		{name: "tent", expected: TentFilter{}},
+		{name: "gaussian", expected: GaussianFilter{Alpha: 2}},
+		{name: "mitchell", expected: MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}},
+		{name: "lanczos", err: true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseFilter(test.name)
+		if test.err && err == nil {
+			t.Errorf("ParseFilter(%s) expected an error", test.name)
+		}
+		if !test.err && result != test.expected {
+			t.Errorf("ParseFilter(%s) expected %v, got %v", test.name, test.expected, result)
+		}
+	}
+}
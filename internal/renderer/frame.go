@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+// Frame holds the rendered image and the buffers that were collected alongside it.
+type Frame struct {
+	Color   canvas.Canvas
+	Samples [][]int // The number of rays traced for every pixel.
+	Passes  map[Pass]canvas.Canvas
+}
+
+func newFrame(width, height int, passes []Pass) *Frame {
+	samples := make([][]int, width)
+	for x := 0; x < width; x++ {
+		samples[x] = make([]int, height)
+	}
+	frame := &Frame{
+		Color:   canvas.New(width, height),
+		Samples: samples,
+		Passes:  make(map[Pass]canvas.Canvas, len(passes)),
+	}
+	for _, p := range passes {
+		frame.Passes[p] = canvas.New(width, height)
+	}
+	return frame
+}
+
+// SampleHeatmap visualizes the number of rays traced for every pixel.
+// The pixels go from black (fewest samples) through red and yellow to white (most samples).
+func (f *Frame) SampleHeatmap() canvas.Canvas {
+	width := len(f.Samples)
+	height := 0
+	most := 0
+	for x := 0; x < width; x++ {
+		height = len(f.Samples[x])
+		for y := 0; y < height; y++ {
+			most = max(most, f.Samples[x][y])
+		}
+	}
+
+	img := canvas.New(width, height)
+	if most == 0 {
+		return img
+	}
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img[x][y] = heat(float64(f.Samples[x][y]) / float64(most))
+		}
+	}
+	return img
+}
+
+// maps a value between 0 and 1 onto a black, red, yellow, white scale.
+func heat(v float64) color.Color {
+	return color.New(
+		min(max(3*v, 0), 1),
+		min(max(3*v-1, 0), 1),
+		min(max(3*v-2, 0), 1),
+	)
+}
@@ -0,0 +1,36 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+)
+
+func TestSampleHeatmap(t *testing.T) {
+	frame := newFrame(2, 2, nil)
+	frame.Samples[0][0] = 0
+	frame.Samples[1][0] = 2
+	frame.Samples[0][1] = 4
+	frame.Samples[1][1] = 6
+
+	img := frame.SampleHeatmap()
+	var tests = []struct {
+		x, y     int
+		expected color.Color
+	}{
+		{x: 0, y: 0, expected: color.Black()},
+		{x: 1, y: 0, expected: color.New(1, 0, 0)},
+		{x: 0, y: 1, expected: color.New(1, 1, 0)},
+		{x: 1, y: 1, expected: color.White()},
+	}
+	for _, test := range tests {
+		if result := img[test.x][test.y]; !result.Equal(test.expected) {
+			t.Errorf("SampleHeatmap pixel (%d, %d) expected %s, got %s", test.x, test.y, test.expected, result)
+		}
+	}
+
+	// An empty frame is black
+	if result := newFrame(1, 1, nil).SampleHeatmap()[0][0]; !result.Equal(color.Black()) {
+		t.Errorf("SampleHeatmap expected black, got %s", result)
+	}
+}
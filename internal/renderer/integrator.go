@@ -0,0 +1,175 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/light"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// Integrator computes the light leaving a hit towards the eye.
+// It decides which paths the light can take through the scene.
+type Integrator interface {
+	Shade(scene *scenes.Scene, comps Computations) Shading
+}
+
+// Shading holds the parts that make up the light leaving a hit, they are kept apart for the render passes.
+type Shading struct {
+	Emitted   color.Color // the light given off by the surface itself.
+	Direct    color.Color // the light arriving straight from the lights, for Whitted it includes the ambient term.
+	Diffuse   color.Color // the light arriving from other surfaces that is scattered by the surface.
+	Reflected color.Color // the light arriving from the mirror direction.
+	Refracted color.Color // the light passing through the surface.
+}
+
+func (s Shading) Color() color.Color {
+	return color.Add(color.Add(color.Add(s.Emitted, s.Direct), s.Diffuse), color.Add(s.Reflected, s.Refracted))
+}
+
+// Adjusts the reflection and the refraction. Materials that are both reflective and transparent
+// reflect more at grazing angles, the Fresnel effect is approximated with Schlick's formula.
+func (s *Shading) specular(comps Computations) {
+	mat := comps.Shape.Material()
+	if mat.Reflective > 0 && mat.Transparency > 0 {
+		reflectance := comps.schlick()
+		s.Reflected = s.Reflected.Scalar(reflectance)
+		s.Refracted = s.Refracted.Scalar(1 - reflectance)
+	}
+}
+
+// Computes the light arriving along a ray with the integrator.
+func radiance(scene *scenes.Scene, integrator Integrator, r *ray.Ray) color.Color {
+	intersections := intersect(scene, r)
+	hit := intersections.Hit()
+	if hit.Empty() {
+		return color.Black()
+	}
+
+	return integrator.Shade(scene, prepareComputations(hit, r, intersections)).Color()
+}
+
+// Whitted is the classic recursive ray tracer. The lights are shaded with the Phong model and the rays
+// continue only into the perfect mirror and refraction directions. Light bouncing between diffuse surfaces
+// is approximated by the constant ambient term of the materials.
+type Whitted struct{}
+
+func (w Whitted) Shade(scene *scenes.Scene, comps Computations) Shading {
+	return shade(scene, comps)
+}
+
+// PathTracer is a unidirectional Monte Carlo path tracer. Besides the light of the Whitted tracer
+// it follows the light bouncing between diffuse surfaces, so the ambient term of the materials is not used.
+// Every hit samples the lights directly with shadow rays (next-event estimation) and continues
+// in a random direction, preferring the ones close to the normal (cosine weighted hemisphere sampling).
+// The result is noisy, it needs many samples per pixel.
+type PathTracer struct {
+	MaxDepth int // the number of bounces after which the paths end.
+	// Paths longer than RouletteDepth bounces end randomly, dark surfaces end them more likely (Russian roulette).
+	// The surviving paths are brightened to make up for the ended ones, so the image stays the same on average.
+	RouletteDepth int
+}
+
+// NewPathTracer creates a path tracer with depths that suit most scenes.
+func NewPathTracer() PathTracer {
+	return PathTracer{MaxDepth: 8, RouletteDepth: 3}
+}
+
+func (p PathTracer) String() string {
+	return fmt.Sprintf("PathTracer(max depth: %d, roulette depth: %d)", p.MaxDepth, p.RouletteDepth)
+}
+
+func (p PathTracer) Shade(scene *scenes.Scene, comps Computations) Shading {
+	s := Shading{Emitted: comps.Shape.Material().Emission}
+	for i := 0; i < len(scene.Lights); i++ {
+		s.Direct = color.Add(s.Direct, light.DirectLighting(
+			comps.Shape,
+			scene.Lights[i],
+			comps.OverPoint,
+			comps.EyeV,
+			comps.NormalV,
+			visibility(scene, scene.Lights[i], comps.OverPoint)))
+	}
+	// the BSDFs follow the Phong conventions, a white surface reflects the full light. Physically it
+	// reflects 1/π of it in each direction, the diffuse bounces below collect the light with that share.
+	s.Direct = s.Direct.Scalar(1 / math.Pi)
+
+	// the camera rays start with the full bounce limit and every bounce takes one.
+	depth := ray.BounceLimit - comps.BounceLimit
+	if depth >= p.MaxDepth {
+		return s
+	}
+
+	mat := comps.Shape.Material()
+	albedo := mat.BSDF().Albedo(mat, shapes.ColorAt(comps.Point, comps.Shape))
+	rough := comps.microfacet()
+	reflectance := rough.reflectance()
+	weight := 1.0
+	if depth >= p.RouletteDepth {
+		survival := min(max(albedo.R, albedo.G, albedo.B, reflectance.R, reflectance.G, reflectance.B, mat.Transparency), 0.95)
+		if rand.Float64() >= survival {
+			return s
+		}
+		weight = 1 / survival
+	}
+
+	if mat.Diffuse > 0 {
+		// the cosine of the diffuse surface and the density of the samples cancel out.
+		s.Diffuse = color.HadamardProduct(albedo, p.bounce(scene, diffuseRay(comps))).Scalar(weight)
+	}
+	if reflectance != color.Black() {
+		s.Reflected = color.HadamardProduct(radiance(scene, p, reflectedRay(rough)), reflectance).Scalar(weight)
+	}
+	if mat.Transparency > 0 {
+		if r := refractedRay(rough); r != nil {
+			s.Refracted = radiance(scene, p, r).Scalar(mat.Transparency * weight)
+		}
+	}
+	s.specular(rough)
+
+	return s
+}
+
+// Computes the light arriving along a diffuse bounce. The emitters that are sampled as lights
+// were already counted by next-event estimation, their emission is skipped to not count them twice.
+// Mirrors and refractions can't sample the lights, they keep the emission.
+func (p PathTracer) bounce(scene *scenes.Scene, r *ray.Ray) color.Color {
+	intersections := intersect(scene, r)
+	hit := intersections.Hit()
+	if hit.Empty() {
+		return color.Black()
+	}
+
+	s := p.Shade(scene, prepareComputations(hit, r, intersections))
+	if sampledEmitter(scene, hit.Shape()) {
+		s.Emitted = color.Black()
+	}
+	return s.Color()
+}
+
+// Reports whether the shape is part of a light of the scene.
+func sampledEmitter(scene *scenes.Scene, shape shapes.Shape) bool {
+	for i := 0; i < len(scene.Lights); i++ {
+		if mesh, ok := scene.Lights[i].(*light.MeshLight); ok && mesh.Contains(shape) {
+			return true
+		}
+	}
+	return false
+}
+
+// A ray leaving the surface in a random direction, with a density proportional to the cosine of the normal.
+func diffuseRay(comps Computations) *ray.Ray {
+	u, v := tuple.Basis(comps.NormalV)
+	x, y, z := utils.CosineHemisphere(rand.Float64(), rand.Float64())
+	direction := tuple.Add(tuple.Add(u.Scalar(x), v.Scalar(y)), comps.NormalV.Scalar(z))
+
+	r := ray.New(comps.OverPoint, direction)
+	r.BounceLimit = comps.BounceLimit - 1
+	return r
+}
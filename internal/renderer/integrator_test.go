@@ -0,0 +1,189 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/light"
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// A floor lit only through the ceiling, a slab hides the light from the floor below it.
+func ceilingScene() (*scenes.Scene, shapes.Shape) {
+	floor := shapes.NewPlane()
+	ceiling := shapes.NewPlane()
+	ceiling.SetTransform(matrix.Translation(0, 4, 0))
+	slab := shapes.NewCube()
+	slab.SetTransform(matrix.Multiply(matrix.Translation(0, 2, 0), matrix.Scaling(1, 0.1, 1)))
+	for _, s := range []shapes.Shape{floor, ceiling, slab} {
+		s.Material().Ambient = 0
+	}
+
+	l := light.NewLight(tuple.NewPoint(0, 3, 0), color.White())
+	return scenes.New([]shapes.Shape{floor, ceiling, slab}, []light.Light{l}), floor
+}
+
+func TestWhittedShade(t *testing.T) {
+	scene := scenes.Default()
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	i := shapes.NewIntersection(4, scene.Shapes[0])
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+
+	if result, expected := (Whitted{}).Shade(scene, comps).Color(), shadeHit(scene, comps); !result.Equal(expected) {
+		t.Errorf("Whitted.Shade expected %s, got %s", expected, result)
+	}
+}
+
+func TestPathTracerDirect(t *testing.T) {
+	// Without bounces only the light arriving straight from the lights remains, the ambient term is not used.
+	scene := scenes.Default()
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	i := shapes.NewIntersection(4, scene.Shapes[0])
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+
+	result := PathTracer{MaxDepth: 0}.Shade(scene, comps)
+	// The light is scattered with the normalised BSDF
+	expected := light.DirectLighting(comps.Shape, scene.Lights[0], comps.OverPoint, comps.EyeV, comps.NormalV, light.Unblocked).Scalar(1 / math.Pi)
+	if !result.Direct.Equal(expected) || !result.Color().Equal(expected) {
+		t.Errorf("PathTracer.Shade expected %s, got %s", expected, result.Color())
+	}
+}
+
+func TestPathTracerIndirect(t *testing.T) {
+	scene, floor := ceilingScene()
+	r := ray.New(tuple.NewPoint(0, 1, -0.5), tuple.NewVector(0, -1, 0))
+	i := shapes.NewIntersection(1, floor)
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+
+	// The Whitted tracer only sees the shadow
+	if result := (Whitted{}).Shade(scene, comps).Color(); !result.Equal(color.Black()) {
+		t.Errorf("Whitted.Shade expected black in the shadow, got %s", result)
+	}
+
+	// The path tracer collects the light bouncing off the ceiling
+	tracer := NewPathTracer()
+	var sum color.Color
+	for n := 0; n < 200; n++ {
+		s := tracer.Shade(scene, comps)
+		if !s.Direct.Equal(color.Black()) {
+			t.Fatalf("PathTracer.Shade expected no direct light in the shadow, got %s", s.Direct)
+		}
+		sum = color.Add(sum, s.Diffuse)
+	}
+	if sum.R <= 0 {
+		t.Errorf("PathTracer.Shade expected indirect light in the shadow, got %s", sum)
+	}
+
+	// The paths end at the maximum depth
+	r.BounceLimit = ray.BounceLimit - tracer.MaxDepth
+	comps = prepareComputations(i, r, shapes.Intersections{i})
+	if result := tracer.Shade(scene, comps).Color(); !result.Equal(color.Black()) {
+		t.Errorf("PathTracer.Shade expected no light after the maximum depth, got %s", result)
+	}
+}
+
+func TestDiffuseRay(t *testing.T) {
+	r := ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0))
+	i := shapes.NewIntersection(1, shapes.NewPlane())
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+
+	for n := 0; n < 100; n++ {
+		d := diffuseRay(comps)
+		if d.Direction.Y < 0 || !d.Origin.Equal(comps.OverPoint) || d.BounceLimit != comps.BounceLimit-1 {
+			t.Errorf("diffuseRay expected a ray leaving the surface, got %s", d)
+		}
+	}
+}
+
+// A floor lit by a glowing panel above it.
+func panelScene() (*scenes.Scene, shapes.Shape, *shapes.Model) {
+	floor := shapes.NewPlane()
+	floor.Material().Ambient = 0
+
+	panel := shapes.NewModel(`v -1 0 -1
+v 1 0 -1
+v 1 0 1
+v -1 0 1
+f 1 2 3 4
+`)
+	mat := materials.DefaultMaterial()
+	mat.Emission = color.New(4, 4, 4)
+	panel.SetMaterial(mat)
+	panel.SetTransform(matrix.Translation(0, 2, 0))
+	panel.CalculateBoundingBox()
+
+	l := light.NewMeshLight(panel.Triangles(), 2)
+	return scenes.New([]shapes.Shape{floor, panel}, []light.Light{l}), floor, panel
+}
+
+func TestEmission(t *testing.T) {
+	// Emissive surfaces are visible without lights
+	sphere := shapes.NewSphere()
+	sphere.Material().Emission = color.New(2, 1, 0)
+	scene := scenes.New([]shapes.Shape{sphere}, nil)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	for _, integrator := range []Integrator{Whitted{}, NewPathTracer()} {
+		if result := radiance(scene, integrator, r); !result.Equal(color.New(2, 1, 0)) {
+			t.Errorf("%T expected the emission, got %s", integrator, result)
+		}
+	}
+}
+
+func TestMeshLightIlluminates(t *testing.T) {
+	scene, floor, panel := panelScene()
+	r := ray.New(tuple.NewPoint(0, 1, -0.5), tuple.NewVector(0, -1, 0))
+	i := shapes.NewIntersection(1, floor)
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+
+	lit := (Whitted{}).Shade(scene, comps).Color()
+	if lit.R <= 0 {
+		t.Errorf("expected the panel to light the floor, got %s", lit)
+	}
+
+	// The triangles of the panel are lights, the path tracer doesn't count them twice
+	if !sampledEmitter(scene, panel.Triangles()[0]) || sampledEmitter(scene, floor) {
+		t.Errorf("expected only the panel to be sampled as a light")
+	}
+	up := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0))
+	if result := (PathTracer{MaxDepth: 0}).bounce(scene, up); !result.Equal(color.Black()) {
+		t.Errorf("PathTracer.bounce expected to skip the emission of the panel, got %s", result)
+	}
+
+	// Objects between the panel and the floor cast shadows
+	blocker := shapes.NewCube()
+	blocker.SetTransform(matrix.Multiply(matrix.Translation(0, 1, 0), matrix.Scaling(3, 0.1, 3)))
+	scene.Shapes = append(scene.Shapes, blocker)
+	if result := (Whitted{}).Shade(scene, comps).Color(); !result.Equal(color.Black()) {
+		t.Errorf("expected the floor to be in shadow, got %s", result)
+	}
+}
+
+func TestPathTracerDirectMatchesIndirect(t *testing.T) {
+	// The light of the panel is the same whether it is sampled as a light or found by the diffuse bounces
+	// The diffuse bounces don't collect the highlight
+	scene, floor, _ := panelScene()
+	floor.Material().Specular = 0
+	r := ray.New(tuple.NewPoint(0, 1, -0.5), tuple.NewVector(0, -1, 0))
+	i := shapes.NewIntersection(1, floor)
+	comps := prepareComputations(i, r, shapes.Intersections{i})
+	tracer := PathTracer{MaxDepth: 1, RouletteDepth: 1}
+
+	const n = 20000
+	var direct, indirect color.Color
+	unsampled := scenes.New(scene.Shapes, nil)
+	for k := 0; k < n; k++ {
+		direct = color.Add(direct, tracer.Shade(scene, comps).Direct)
+		indirect = color.Add(indirect, tracer.Shade(unsampled, comps).Diffuse)
+	}
+	direct, indirect = direct.Scalar(1.0/n), indirect.Scalar(1.0/n)
+	if math.Abs(direct.R-indirect.R) > indirect.R*0.05 {
+		t.Errorf("expected the direct light %s to match the bounced light %s", direct, indirect)
+	}
+}
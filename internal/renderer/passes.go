@@ -0,0 +1,159 @@
+package renderer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+)
+
+// Pass is an additional buffer rendered alongside the image, also known as an AOV.
+// Compositing tools use them to adjust parts of the image without rendering it again.
+type Pass int
+
+const (
+	DepthPass      Pass = iota // the distance from the camera to the first hit, infinite for the background.
+	NormalPass                 // the world space normal of the first hit, facing the camera.
+	AlbedoPass                 // the color of the surface without lighting.
+	ObjectIDPass               // the number of the top level object that was hit, starting from 1, 0 for the background.
+	DirectPass                 // the light arriving straight from the lights and the light of emissive surfaces.
+	IndirectPass               // the light arriving from other surfaces, direct + indirect = image.
+	ReflectionPass             // the reflected part of the indirect light.
+	RefractionPass             // the refracted part of the indirect light.
+	passCount
+)
+
+func (p Pass) String() string {
+	switch p {
+	case DepthPass:
+		return "depth"
+	case NormalPass:
+		return "normal"
+	case AlbedoPass:
+		return "albedo"
+	case ObjectIDPass:
+		return "object_id"
+	case DirectPass:
+		return "direct"
+	case IndirectPass:
+		return "indirect"
+	case ReflectionPass:
+		return "reflection"
+	case RefractionPass:
+		return "refraction"
+	default:
+		return fmt.Sprintf("Pass(%d)", int(p))
+	}
+}
+
+// ParsePass converts the name of a pass into a Pass.
+func ParsePass(name string) (Pass, error) {
+	for p := Pass(0); p < passCount; p++ {
+		if p.String() == name {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown pass: %s", name)
+}
+
+// Lighting reports whether the pass holds light, like the image itself.
+// These passes can be post-processed like the image, the others hold data.
+func (p Pass) Lighting() bool {
+	return p >= DirectPass
+}
+
+// Normalize scales a data pass into the 0-1 range of low dynamic range formats, which would clip it.
+// Depths and object numbers are divided by their largest value, the background depth becomes white.
+// Normals are moved from -1..1 to 0..1. The other passes are returned as they are.
+func (p Pass) Normalize(c canvas.Canvas) canvas.Canvas {
+	var convert func(v float64) float64
+	switch p {
+	case DepthPass, ObjectIDPass:
+		largest := 0.0
+		for x := range c {
+			for y := range c[x] {
+				if v := c[x][y].R; !math.IsInf(v, 1) {
+					largest = math.Max(largest, v)
+				}
+			}
+		}
+		if largest == 0 {
+			largest = 1
+		}
+		convert = func(v float64) float64 { return math.Min(v/largest, 1) }
+	case NormalPass:
+		convert = func(v float64) float64 { return (v + 1) / 2 }
+	default:
+		return c
+	}
+
+	result := canvas.New(len(c), len(c[0]))
+	for x := range c {
+		for y := range c[x] {
+			result[x][y] = color.New(convert(c[x][y].R), convert(c[x][y].G), convert(c[x][y].B))
+		}
+	}
+	return result
+}
+
+// Averaging depths or object numbers across an edge creates values that belong to neither side,
+// so these passes take the sample closest to the pixel center instead of filtering.
+func (p Pass) filtered() bool {
+	return p != DepthPass && p != ObjectIDPass
+}
+
+// passes holds the value of every pass for a single sample or pixel.
+type passes [passCount]color.Color
+
+// the passes of a ray that missed everything.
+func backgroundPasses() passes {
+	var p passes
+	p[DepthPass] = gray(math.Inf(1))
+	return p
+}
+
+// Computes the color of a ray with the integrator, together with the passes of the first hit.
+func passesAt(scene *scenes.Scene, integrator Integrator, r *ray.Ray) (color.Color, passes) {
+	intersections := intersect(scene, r)
+	hit := intersections.Hit()
+	if hit.Empty() {
+		return color.Black(), backgroundPasses()
+	}
+
+	comps := prepareComputations(hit, r, intersections)
+	s := integrator.Shade(scene, comps)
+
+	var p passes
+	p[DepthPass] = gray(comps.T)
+	p[NormalPass] = color.New(comps.NormalV.X, comps.NormalV.Y, comps.NormalV.Z)
+	p[AlbedoPass] = shapes.ColorAt(comps.Point, comps.Shape)
+	p[ObjectIDPass] = gray(float64(objectID(scene, comps.Shape)))
+	p[DirectPass] = color.Add(s.Emitted, s.Direct)
+	p[IndirectPass] = color.Add(s.Diffuse, color.Add(s.Reflected, s.Refracted))
+	p[ReflectionPass] = s.Reflected
+	p[RefractionPass] = s.Refracted
+
+	return s.Color(), p
+}
+
+// Returns the number of the top level object the shape belongs to, starting from 1.
+// Shapes inside groups and models get the number of the outermost group.
+func objectID(scene *scenes.Scene, shape shapes.Shape) int {
+	for shape.Parent() != nil {
+		shape = shape.Parent()
+	}
+	for i := 0; i < len(scene.Shapes); i++ {
+		if scene.Shapes[i] == shape {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func gray(v float64) color.Color {
+	return color.New(v, v, v)
+}
@@ -0,0 +1,164 @@
+package renderer
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/shapes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestParsePass(t *testing.T) {
+	for p := Pass(0); p < passCount; p++ {
+		if result, err := ParsePass(p.String()); err != nil || result != p {
+			t.Errorf("ParsePass(%s) expected %s, got %s", p, p, result)
+		}
+	}
+
+	if _, err := ParsePass("beauty"); err == nil {
+		t.Errorf("ParsePass(beauty) expected an error")
+	}
+}
+
+func TestPassesAt(t *testing.T) {
+	scene := scenes.Default()
+	mat := scene.Shapes[0].Material()
+	mat.Reflective = 0.5
+	// a wall behind the light, the sphere reflects it.
+	wall := shapes.NewPlane()
+	wall.SetTransform(matrix.Multiply(matrix.Translation(0, 0, -20), matrix.RotationX(math.Pi/2)))
+	scene.Shapes = append(scene.Shapes, wall)
+
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	col, p := passesAt(scene, Whitted{}, r)
+
+	if expected := colorAt(scene, r); !col.Equal(expected) {
+		t.Errorf("passesAt expected the color %s, got %s", expected, col)
+	}
+	if !utils.FloatEquals(p[DepthPass].R, 4) {
+		t.Errorf("passesAt expected a depth of 4, got %s", p[DepthPass])
+	}
+	if expected := color.New(0, 0, -1); !p[NormalPass].Equal(expected) {
+		t.Errorf("passesAt expected the normal %s, got %s", expected, p[NormalPass])
+	}
+	if expected := color.New(0.8, 1.0, 0.6); !p[AlbedoPass].Equal(expected) {
+		t.Errorf("passesAt expected the albedo %s, got %s", expected, p[AlbedoPass])
+	}
+	if !p[ObjectIDPass].Equal(color.New(1, 1, 1)) {
+		t.Errorf("passesAt expected the first object, got %s", p[ObjectIDPass])
+	}
+
+	// The reflection sees the wall, the light passes add up to the color
+	if p[ReflectionPass].Equal(color.Black()) || !p[RefractionPass].Equal(color.Black()) {
+		t.Errorf("passesAt expected only a reflection, got %s and %s", p[ReflectionPass], p[RefractionPass])
+	}
+	if !p[IndirectPass].Equal(p[ReflectionPass]) {
+		t.Errorf("passesAt expected the indirect light %s, got %s", p[ReflectionPass], p[IndirectPass])
+	}
+	if sum := color.Add(p[DirectPass], p[IndirectPass]); !sum.Equal(col) {
+		t.Errorf("passesAt expected direct + indirect to be %s, got %s", col, sum)
+	}
+
+	// Missing everything
+	r = ray.New(tuple.NewPoint(0, 5, -5), tuple.NewVector(0, 0, 1))
+	_, p = passesAt(scene, Whitted{}, r)
+	if !math.IsInf(p[DepthPass].R, 1) || !p[ObjectIDPass].Equal(color.Black()) {
+		t.Errorf("passesAt expected the background, got %s and %s", p[DepthPass], p[ObjectIDPass])
+	}
+}
+
+func TestObjectID(t *testing.T) {
+	scene := scenes.Default()
+	inner := shapes.NewSphere()
+	group := shapes.NewGroup()
+	group.AddChild(inner)
+	scene.Shapes = append(scene.Shapes, group)
+
+	var tests = []struct {
+		shape    shapes.Shape
+		expected int
+	}{
+		{shape: scene.Shapes[0], expected: 1},
+		{shape: scene.Shapes[1], expected: 2},
+		{shape: inner, expected: 3},
+		{shape: shapes.NewSphere(), expected: 0},
+	}
+
+	for _, test := range tests {
+		if result := objectID(scene, test.shape); result != test.expected {
+			t.Errorf("objectID expected %d, got %d", test.expected, result)
+		}
+	}
+}
+
+func TestRenderPasses(t *testing.T) {
+	scene := scenes.Default()
+	c := camera.New(11, 11, math.Pi/2)
+	c.SetTransform(camera.ViewTransformation(
+		tuple.NewPoint(0, 0, -5),
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewVector(0, 1, 0),
+	))
+	opts := DefaultOptions()
+	opts.Samples = 3
+	opts.Passes = []Pass{DepthPass, DirectPass}
+
+	frame, _ := RenderFrame(context.Background(), c, scene, opts)
+	if len(frame.Passes) != 2 {
+		t.Fatalf("RenderFrame expected 2 passes, got %d", len(frame.Passes))
+	}
+
+	// Depth comes from the sample closest to the pixel center, it is not averaged across the edge
+	_, center := passesAt(scene, Whitted{}, c.RayForPixel(6, 5))
+	if depth := frame.Passes[DepthPass][6][5].R; !utils.FloatEquals(depth, center[DepthPass].R) {
+		t.Errorf("RenderFrame expected the depth %f, got %f", center[DepthPass].R, depth)
+	}
+	if depth := frame.Passes[DepthPass][0][0].R; !math.IsInf(depth, 1) {
+		t.Errorf("RenderFrame expected an infinite background depth, got %f", depth)
+	}
+
+	// Without reflections and refractions the direct light is the whole image
+	if !frame.Passes[DirectPass][6][5].Equal(frame.Color[6][5]) {
+		t.Errorf("RenderFrame expected the direct pass %s, got %s", frame.Color[6][5], frame.Passes[DirectPass][6][5])
+	}
+}
+
+func TestNormalizePass(t *testing.T) {
+	depth := canvas.New(3, 1)
+	depth[0][0], depth[1][0], depth[2][0] = gray(2), gray(8), gray(math.Inf(1))
+	ids := canvas.New(2, 1)
+	ids[0][0], ids[1][0] = gray(0), gray(4)
+	normals := canvas.New(1, 1)
+	normals[0][0] = color.New(-1, 0, 1)
+	direct := canvas.New(1, 1)
+	direct[0][0] = gray(3)
+
+	var tests = []struct {
+		pass     Pass
+		canvas   canvas.Canvas
+		expected []color.Color
+	}{
+		{pass: DepthPass, canvas: depth, expected: []color.Color{gray(0.25), gray(1), gray(1)}},
+		{pass: ObjectIDPass, canvas: ids, expected: []color.Color{gray(0), gray(1)}},
+		{pass: NormalPass, canvas: normals, expected: []color.Color{color.New(0, 0.5, 1)}},
+		// light is left to the post-processing
+		{pass: DirectPass, canvas: direct, expected: []color.Color{gray(3)}},
+	}
+
+	for _, test := range tests {
+		result := test.pass.Normalize(test.canvas)
+		for x, expected := range test.expected {
+			if !result[x][0].Equal(expected) {
+				t.Errorf("Normalize(%s) at %d expected %s, got %s", test.pass, x, expected, result[x][0])
+			}
+		}
+	}
+}
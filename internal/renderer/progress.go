@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Progress is a snapshot of how far a render has gotten.
+type Progress struct {
+	TilesDone, TilesTotal   int
+	PixelsDone, PixelsTotal int
+	Elapsed                 time.Duration // time since the render started.
+	ETA                     time.Duration // estimated time until the render finishes, 0 until the first tile is done.
+}
+
+// Fraction returns the completed part of the render between 0 and 1.
+func (p Progress) Fraction() float64 {
+	if p.PixelsTotal == 0 {
+		return 1
+	}
+	return float64(p.PixelsDone) / float64(p.PixelsTotal)
+}
+
+// Observer is notified about the progress of a render.
+// All methods are called from the goroutine that called RenderContext, never concurrently.
+type Observer interface {
+	// Started is called once before the first tile is rendered.
+	Started(p Progress)
+	// TileDone is called every time a tile is finished.
+	TileDone(p Progress)
+	// Finished is called once when the render stops, err is non-nil if it was cancelled.
+	Finished(p Progress, err error)
+}
+
+// keeps track of the progress and estimates the remaining time.
+type tracker struct {
+	progress Progress
+	start    time.Time
+}
+
+func newTracker(queue []tile, pixels int) *tracker {
+	return &tracker{
+		progress: Progress{TilesTotal: len(queue), PixelsTotal: pixels},
+		start:    time.Now(),
+	}
+}
+
+func (t *tracker) add(done tile) Progress {
+	t.progress.TilesDone++
+	t.progress.PixelsDone += done.pixels()
+	return t.snapshot()
+}
+
+func (t *tracker) snapshot() Progress {
+	t.progress.Elapsed = time.Since(t.start)
+	if t.progress.PixelsDone > 0 {
+		remaining := float64(t.progress.PixelsTotal-t.progress.PixelsDone) / float64(t.progress.PixelsDone)
+		t.progress.ETA = time.Duration(float64(t.progress.Elapsed) * remaining)
+	}
+	return t.progress
+}
+
+// ConsoleObserver prints a single, continuously updated progress line.
+type ConsoleObserver struct {
+	w    io.Writer
+	last int // last printed percentage, avoids flooding the output.
+}
+
+func NewConsoleObserver(w io.Writer) *ConsoleObserver {
+	return &ConsoleObserver{w: w, last: -1}
+}
+
+func (o *ConsoleObserver) Started(p Progress) {
+	o.print(p)
+}
+
+func (o *ConsoleObserver) TileDone(p Progress) {
+	if percent := int(math.Round(p.Fraction() * 100)); percent != o.last {
+		o.print(p)
+	}
+}
+
+func (o *ConsoleObserver) Finished(p Progress, err error) {
+	o.print(p)
+	if err != nil {
+		fmt.Fprintf(o.w, "\nStopped: %s\n", err.Error())
+		return
+	}
+	fmt.Fprintf(o.w, "\nDone!\n")
+}
+
+func (o *ConsoleObserver) print(p Progress) {
+	o.last = int(math.Round(p.Fraction() * 100))
+	fmt.Fprintf(o.w, "\rRendering: %d%% (%d/%d tiles, ETA %s)   ", o.last, p.TilesDone, p.TilesTotal, p.ETA.Round(time.Second))
+}
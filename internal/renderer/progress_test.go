@@ -0,0 +1,132 @@
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// records every notification it receives.
+type recordingObserver struct {
+	started  []Progress
+	tiles    []Progress
+	finished []Progress
+	err      error
+	// cancels the render after the given number of tiles.
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (o *recordingObserver) Started(p Progress) {
+	o.started = append(o.started, p)
+}
+
+func (o *recordingObserver) TileDone(p Progress) {
+	o.tiles = append(o.tiles, p)
+	if o.cancel != nil && len(o.tiles) == o.cancelAfter {
+		o.cancel()
+	}
+}
+
+func (o *recordingObserver) Finished(p Progress, err error) {
+	o.finished = append(o.finished, p)
+	o.err = err
+}
+
+func testCamera(width, height int) *camera.Camera {
+	c := camera.New(width, height, math.Pi/2)
+	c.SetTransform(camera.ViewTransformation(
+		tuple.NewPoint(0, 0, -5),
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewVector(0, 1, 0),
+	))
+	return c
+}
+
+func TestRenderContextProgress(t *testing.T) {
+	observer := &recordingObserver{}
+	c := testCamera(20, 10)
+	_, err := RenderContext(context.Background(), c, scenes.Default(), Options{Workers: 2, TileSize: 5, Observer: observer})
+
+	if err != nil {
+		t.Errorf("RenderContext unexpected error: %s", err)
+	}
+	if len(observer.started) != 1 || len(observer.finished) != 1 {
+		t.Fatalf("RenderContext expected one start and finish, got %d and %d", len(observer.started), len(observer.finished))
+	}
+	if len(observer.tiles) != 8 {
+		t.Errorf("RenderContext expected 8 tile notifications, got %d", len(observer.tiles))
+	}
+	for i, p := range observer.tiles {
+		if p.TilesDone != i+1 || p.TilesTotal != 8 || p.PixelsDone != (i+1)*25 || p.PixelsTotal != 200 {
+			t.Errorf("RenderContext incorrect progress %+v after tile %d", p, i+1)
+		}
+	}
+	if last := observer.finished[0]; last.Fraction() != 1 || last.ETA != 0 {
+		t.Errorf("RenderContext expected a finished render, got %+v", last)
+	}
+}
+
+func TestRenderContextCancel(t *testing.T) {
+	// A cancelled context stops the render and returns the partial image.
+	ctx, cancel := context.WithCancel(context.Background())
+	observer := &recordingObserver{cancelAfter: 1, cancel: cancel}
+	c := testCamera(40, 40)
+	img, err := RenderContext(ctx, c, scenes.Default(), Options{Workers: 1, TileSize: 10, Order: Spiral, Observer: observer})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RenderContext expected context.Canceled, got %v", err)
+	}
+	if !errors.Is(observer.err, context.Canceled) {
+		t.Errorf("Observer expected context.Canceled, got %v", observer.err)
+	}
+	if len(observer.tiles) > 2 {
+		t.Errorf("RenderContext expected at most 2 tiles after cancellation, got %d", len(observer.tiles))
+	}
+	if len(img) != 40 || len(img[0]) != 40 {
+		t.Errorf("RenderContext expected a 40x40 canvas, got %dx%d", len(img), len(img[0]))
+	}
+	// the sphere is in the middle, the first tile of the spiral was rendered, the third was not.
+	if img[18][18].Equal(color.Black()) {
+		t.Errorf("RenderContext expected the first tile to be rendered")
+	}
+	if !img[22][22].Equal(color.Black()) {
+		t.Errorf("RenderContext expected the third tile to be empty, got %s", img[22][22])
+	}
+
+	// An expired deadline renders nothing.
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err = RenderContext(ctx, c, scenes.Default(), DefaultOptions()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("RenderContext expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestConsoleObserver(t *testing.T) {
+	var out bytes.Buffer
+	o := NewConsoleObserver(&out)
+	o.Started(Progress{TilesTotal: 2, PixelsTotal: 10})
+	o.TileDone(Progress{TilesDone: 1, TilesTotal: 2, PixelsDone: 5, PixelsTotal: 10, ETA: 3 * time.Second})
+	// the percentage did not change, nothing is printed.
+	o.TileDone(Progress{TilesDone: 1, TilesTotal: 2, PixelsDone: 5, PixelsTotal: 10, ETA: 2 * time.Second})
+	o.Finished(Progress{TilesDone: 2, TilesTotal: 2, PixelsDone: 10, PixelsTotal: 10}, nil)
+
+	result := out.String()
+	for _, expected := range []string{"Rendering: 0%", "Rendering: 50% (1/2 tiles, ETA 3s)", "Rendering: 100%", "Done!"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("ConsoleObserver output %q does not contain %q", result, expected)
+		}
+	}
+	if strings.Contains(result, "ETA 2s") {
+		t.Errorf("ConsoleObserver printed an unchanged percentage: %q", result)
+	}
+}
@@ -2,8 +2,9 @@
 package renderer
 
 import (
-	"fmt"
+	"context"
 	"math"
+	"runtime"
 	"sync"
 
 	"github.com/kaizencodes/glimpse/internal/camera"
@@ -17,30 +18,104 @@ import (
 )
 
 // The main function that renders the scene pixel by pixel.
+// It is a convenience wrapper around RenderContext using the default options.
 func Render(c *camera.Camera, w *scenes.Scene) canvas.Canvas {
-	total := c.Width * c.Height
-	done := 0
-	img := canvas.New(c.Width, c.Height)
+	img, _ := RenderContext(context.Background(), c, w, DefaultOptions())
+	return img
+}
+
+// RenderContext splits the image into tiles and renders them with a fixed pool of workers.
+// The render stops early when the context is cancelled or its deadline passes, in that case
+// the partially filled canvas is returned together with the context's error.
+func RenderContext(ctx context.Context, c *camera.Camera, w *scenes.Scene, opts Options) (canvas.Canvas, error) {
+	frame, err := RenderFrame(ctx, c, w, opts)
+	return frame.Color, err
+}
+
+// RenderFrame works like RenderContext, but besides the image it also returns
+// the additional buffers that were collected during the render.
+func RenderFrame(ctx context.Context, c *camera.Camera, w *scenes.Scene, opts Options) (*Frame, error) {
+	frame := newFrame(c.Width, c.Height, opts.Passes)
+	queue := tiles(c.Width, c.Height, opts.TileSize, opts.Order)
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan tile)
+	finished := make(chan tile)
 	var wg sync.WaitGroup
 
-	for y := 0; y < c.Height-1; y++ {
-		for x := 0; x < c.Width-1; x++ {
-			wg.Add(1)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range jobs {
+				// skip the tiles that were already queued when the render got cancelled.
+				if ctx.Err() != nil {
+					continue
+				}
+				renderTile(c, w, frame, t, opts)
+				finished <- t
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for _, t := range queue {
+			select {
+			case jobs <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 
-			go func(x, y int) {
-				defer wg.Done()
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
 
-				r := c.RayForPixel(x, y)
-				col := colorAt(w, r)
-				img[x][y] = col
-				done += 1
-				fmt.Printf("\rRendering: %d%%", int(math.Round((float64(done)/float64(total))*100)))
-			}(x, y)
+	// Progress is only tracked on this goroutine, so the counters need no synchronization.
+	progress := newTracker(queue, c.Width*c.Height)
+	if opts.Observer != nil {
+		opts.Observer.Started(progress.snapshot())
+	}
+	for t := range finished {
+		p := progress.add(t)
+		if opts.Observer != nil {
+			opts.Observer.TileDone(p)
+		}
+	}
+
+	// tiles that were already handed out are finished before returning,
+	// so nothing writes to the canvas after the caller gets it back.
+	err := ctx.Err()
+	if opts.Observer != nil {
+		opts.Observer.Finished(progress.snapshot(), err)
+	}
+	return frame, err
+}
+
+// Renders every pixel of a tile. Each pixel belongs to exactly one tile,
+// so the workers never write to the same part of the canvas.
+func renderTile(c *camera.Camera, w *scenes.Scene, frame *Frame, t tile, opts Options) {
+	for y := t.y0; y < t.y1; y++ {
+		for x := t.x0; x < t.x1; x++ {
+			var p pixel
+			if opts.Adaptive.enabled() {
+				p = sampleAdaptive(c, w, x, y, opts)
+			} else {
+				p = samplePixel(c, w, x, y, opts)
+			}
+			frame.Color[x][y], frame.Samples[x][y] = p.color, p.samples
+			for pass, img := range frame.Passes {
+				img[x][y] = p.passes[pass]
+			}
 		}
 	}
-	wg.Wait()
-	fmt.Printf("\nDone!")
-	return img
 }
 
 // Computes the color of a pixel.
@@ -56,30 +131,28 @@ func colorAt(scene *scenes.Scene, r *ray.Ray) color.Color {
 
 // helper method for colorAt.
 func shadeHit(scene *scenes.Scene, comps Computations) color.Color {
-	isShadowed := shadowAt(scene, comps.OverPoint)
-	var c color.Color
+	return shade(scene, comps).Color()
+}
+
+// The Whitted shading of a hit.
+func shade(scene *scenes.Scene, comps Computations) Shading {
+	s := Shading{Emitted: comps.Shape.Material().Emission}
 	for i := 0; i < len(scene.Lights); i++ {
-		c = color.Add(c, light.Lighting(
+		s.Direct = color.Add(s.Direct, light.Lighting(
 			comps.Shape,
 			scene.Lights[i],
 			comps.OverPoint,
 			comps.EyeV,
 			comps.NormalV,
-			isShadowed[i]))
+			visibility(scene, scene.Lights[i], comps.OverPoint)))
 
 	}
-	reflected := reflectedColor(scene, comps)
-	refracted := refractedColor(scene, comps)
-	mat := comps.Shape.Material()
-	if mat.Reflective > 0 && mat.Transparency > 0 {
-		reflectance := comps.schlick()
-		reflected = reflected.Scalar(reflectance)
-		refracted = refracted.Scalar(1 - reflectance)
-	}
-	c = color.Add(c, reflected)
-	c = color.Add(c, refracted)
+	rough := comps.microfacet()
+	s.Reflected = reflectedColor(scene, rough)
+	s.Refracted = refractedColor(scene, rough)
+	s.specular(rough)
 
-	return c
+	return s
 }
 
 // Computes all intersections between a ray and the scene objects.
@@ -94,52 +167,104 @@ func intersect(scene *scenes.Scene, r *ray.Ray) shapes.Intersections {
 	return coll
 }
 
-// Determines if a point is in shadow or not.
-func shadowAt(scene *scenes.Scene, point tuple.Tuple) []bool {
-	result := make([]bool, len(scene.Lights))
+// Like intersect, but nothing is skipped beyond the closest hit.
+func intersectAll(scene *scenes.Scene, r *ray.Ray) shapes.Intersections {
+	coll := shapes.Intersections{}
+	for i := 0; i < len(scene.Shapes); i++ {
+		coll = append(coll, shapes.IntersectAll(scene.Shapes[i], r)...)
+	}
+	coll.Sort()
 
-	for i := 0; i < len(scene.Lights); i++ {
-		// Measure the distance from point to the light source by subtracting point from the light position
-		v := tuple.Subtract(scene.Lights[i].Position(), point)
-		// The magnitude of the resulting vector is the distance between the point and the light source.
-		dist := v.Magnitude()
-		// Create a ray from point toward the light source by normalizing the vector.
-		r := ray.New(point, v.Normalize())
-		// check for intersections between the point and the light source.
-		hit := intersect(scene, r).Hit()
-
-		// if there is an intersection then the point is in shadow.
-		if !hit.Empty() && hit.T() < dist {
-			result[i] = true
-		} else {
-			result[i] = false
+	return coll
+}
+
+// Determines which samples of a light are visible from a point, the others are in shadow.
+// The samples are tested one by one while they are shaded, area lights are partially hidden in the penumbra.
+func visibility(scene *scenes.Scene, l light.Light, point tuple.Tuple) light.Visibility {
+	if !l.CastsShadows() {
+		return light.Unblocked
+	}
+	return func(sample light.Sample) bool {
+		return !isOccluded(scene, point, sample)
+	}
+}
+
+// Determines if there is an object between the point and a sample of a light.
+// Objects that don't cast shadows are ignored.
+func isOccluded(scene *scenes.Scene, point tuple.Tuple, sample light.Sample) bool {
+	// Create a ray from point toward the light source.
+	r := ray.New(point, sample.Direction)
+	// check for intersections between the point and the light source.
+	xs := intersect(scene, r)
+
+	// the hierarchies skip everything beyond the closest hit. When that hit doesn't cast shadows
+	// the objects behind it are needed too.
+	for i := 0; i < len(xs); i++ {
+		if xs[i].T() < 0 {
+			continue
+		}
+		if xs[i].T() < sample.Distance && !xs[i].Shape().Material().CastsShadow {
+			xs = intersectAll(scene, r)
 		}
+		break
 	}
-	return result
+
+	// if there is an intersection then the point is in shadow.
+	for i := 0; i < len(xs); i++ {
+		if xs[i].T() < 0 {
+			continue
+		}
+		if xs[i].T() >= sample.Distance {
+			return false
+		}
+		if xs[i].Shape().Material().CastsShadow {
+			return true
+		}
+	}
+	return false
 }
 
 // Computes the color of a reflected ray.
 func reflectedColor(scene *scenes.Scene, comps Computations) color.Color {
-	if comps.Shape.Material().Reflective == 0 || comps.BounceLimit < 1 {
+	reflectance := comps.reflectance()
+	if reflectance == color.Black() || comps.BounceLimit < 1 {
 		return color.Black()
 	}
 
+	c := colorAt(scene, reflectedRay(comps))
+
+	return color.HadamardProduct(c, reflectance)
+}
+
+// The ray bouncing off the surface like off a mirror.
+func reflectedRay(comps Computations) *ray.Ray {
 	// use OverPoint to avoid shadow acne.
 	r := ray.New(comps.OverPoint, comps.ReflectV)
 	r.BounceLimit = comps.BounceLimit - 1
-	c := colorAt(scene, r)
-
-	return c.Scalar(comps.Shape.Material().Reflective)
+	return r
 }
 
 // Computes the color of a refracted ray.
-// Refraction describes how light bends when it passes from one transparent medium to another.
-// uses Snell’s Law which describes the relationship between the angles of the light rays and the refractive indices of the two media.
 func refractedColor(scene *scenes.Scene, comps Computations) color.Color {
 	if comps.Shape.Material().Transparency == 0 || comps.BounceLimit < 1 {
 		return color.Black()
 	}
 
+	refracted := refractedRay(comps)
+	if refracted == nil {
+		return color.Black()
+	}
+
+	// Find the color of the refracted ray, making sure to multiply by the transparency
+	// value to account for any opacity.
+	return colorAt(scene, refracted).Scalar(comps.Shape.Material().Transparency)
+}
+
+// The ray passing through the surface.
+// Refraction describes how light bends when it passes from one transparent medium to another.
+// uses Snell’s Law which describes the relationship between the angles of the light rays and the refractive indices of the two media.
+// Returns nil in case of total internal reflection, when the light can't leave the medium.
+func refractedRay(comps Computations) *ray.Ray {
 	// Find the ratio of first index of refraction to the second.
 	nRatio := comps.N1 / comps.N2
 	// cos(theta i) is the same as the dot product of the two vectors.
@@ -147,7 +272,7 @@ func refractedColor(scene *scenes.Scene, comps Computations) color.Color {
 	// Find sin(theta t)^2 via trigonometric identity
 	sin2T := math.Pow(nRatio, 2) * (1 - math.Pow(cosI, 2))
 	if sin2T > 1 {
-		return color.Black()
+		return nil
 	}
 
 	// Find cos(theta t) via trigonometric identity
@@ -158,7 +283,5 @@ func refractedColor(scene *scenes.Scene, comps Computations) color.Color {
 	refractedRay := ray.New(comps.UnderPoint, direction)
 	refractedRay.BounceLimit = comps.BounceLimit - 1
 
-	// Find the color of the refracted ray, making sure to multiply by the transparency
-	// value to account for any opacity.
-	return colorAt(scene, refractedRay).Scalar(comps.Shape.Material().Transparency)
+	return refractedRay
 }
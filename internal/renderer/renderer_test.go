@@ -252,6 +252,21 @@ func TestRecusingReflection(t *testing.T) {
 	colorAt(scene, r)
 }
 
+// The share of the samples of each light that is hidden from the point.
+func shadowAt(scene *scenes.Scene, point tuple.Tuple) []float64 {
+	result := make([]float64, len(scene.Lights))
+	for i, l := range scene.Lights {
+		visible := visibility(scene, l, point)
+		samples := l.Samples(point)
+		for _, s := range samples {
+			if !visible(s) {
+				result[i] += 1 / float64(len(samples))
+			}
+		}
+	}
+	return result
+}
+
 func TestShadowAt(t *testing.T) {
 	scene := scenes.Default()
 	lights := []light.Light{
@@ -260,40 +275,59 @@ func TestShadowAt(t *testing.T) {
 	}
 	multiLightScene := scenes.New(scene.Shapes, lights)
 
+	// A pane that doesn't cast shadows in front of a blocker, inside a group with a hierarchy.
+	pane := shapes.NewCube()
+	pane.SetTransform(matrix.Multiply(matrix.Translation(0, 1, 0), matrix.Scaling(2, 0.1, 2)))
+	pane.Material().CastsShadow = false
+	blocker := shapes.NewSphere()
+	blocker.SetTransform(matrix.Translation(0, 6, 0))
+	group := shapes.NewGroup()
+	group.AddChild(pane)
+	group.AddChild(blocker)
+	group.CalculateBoundingBoxCascade()
+	group.BuildBVH(1)
+	bvhScene := scenes.New([]shapes.Shape{group}, []light.Light{light.NewLight(tuple.NewPoint(0, 10, 0), color.New(1, 1, 1))})
+
 	var tests = []struct {
 		scene    *scenes.Scene
 		point    tuple.Tuple
-		expected []bool
+		expected []float64
 	}{
 		{
 			// There is no shadow when nothing is collinear with point and light
 			scene:    scene,
 			point:    tuple.NewPoint(0, 10, 0),
-			expected: []bool{false},
+			expected: []float64{0},
 		},
 		{
 			// The shadow when an object is between the point and the light
 			scene:    scene,
 			point:    tuple.NewPoint(10, -10, 10),
-			expected: []bool{true},
+			expected: []float64{1},
 		},
 		{
 			// There is no shadow when an object is behind the light
 			scene:    scene,
 			point:    tuple.NewPoint(-20, 20, -20),
-			expected: []bool{false},
+			expected: []float64{0},
 		},
 		{
 			// There is no shadow when an object is behind the point
 			scene:    scene,
 			point:    tuple.NewPoint(-2, 2, -2),
-			expected: []bool{false},
+			expected: []float64{0},
 		},
 		{
 			// For one light there's shadow, for the other there is not.
 			scene:    multiLightScene,
 			point:    tuple.NewPoint(-2, 2, -2),
-			expected: []bool{false, true},
+			expected: []float64{0, 1},
+		},
+		{
+			// An object that doesn't cast shadows doesn't hide the blocker behind it from the hierarchy.
+			scene:    bvhScene,
+			point:    tuple.NewPoint(0, 0, 0),
+			expected: []float64{1},
 		},
 	}
 
@@ -301,13 +335,73 @@ func TestShadowAt(t *testing.T) {
 		results := shadowAt(test.scene, test.point)
 		for i := 0; i < len(results); i++ {
 			if results[i] != test.expected[i] {
-				t.Errorf("ShadowAt,\npoint:\n%s\nresult:\n%t\nexpected: \n%t", test.point, results[i], test.expected[i])
+				t.Errorf("ShadowAt,\npoint:\n%s\nresult:\n%f\nexpected: \n%f", test.point, results[i], test.expected[i])
 			}
 
 		}
 	}
 }
 
+func TestSoftShadow(t *testing.T) {
+	// The sphere hides a part of an area light, the point is in the penumbra.
+	scene := scenes.Default()
+	scene.Lights = []light.Light{
+		light.NewRectLight(tuple.NewPoint(0, 10, 0), tuple.NewVector(10, 0, 0), tuple.NewVector(0, 0, 10), color.New(1, 1, 1), 8),
+	}
+
+	result := shadowAt(scene, tuple.NewPoint(0, -5, 0))[0]
+	if result <= 0 || result >= 1 {
+		t.Errorf("ShadowAt with an area light expected partial shadow, got %f", result)
+	}
+
+	// Far away from the sphere there is no shadow.
+	result = shadowAt(scene, tuple.NewPoint(20, -5, 0))[0]
+	if result != 0 {
+		t.Errorf("ShadowAt with an area light expected no shadow, got %f", result)
+	}
+}
+
+func TestShadowAtInfinity(t *testing.T) {
+	// Every object along the direction of a light at infinity casts a shadow, no matter how far.
+	scene := scenes.Default()
+	scene.Lights = []light.Light{
+		light.NewDirectionalLight(tuple.NewVector(0, -1, 0), color.New(1, 1, 1)),
+	}
+
+	if result := shadowAt(scene, tuple.NewPoint(0, -1000, 0))[0]; result != 1 {
+		t.Errorf("ShadowAt with a directional light expected shadow, got %f", result)
+	}
+	if result := shadowAt(scene, tuple.NewPoint(5, -5, 0))[0]; result != 0 {
+		t.Errorf("ShadowAt with a directional light expected no shadow, got %f", result)
+	}
+}
+
+func TestShadowFlags(t *testing.T) {
+	point := tuple.NewPoint(10, -10, 10)
+
+	// A light that doesn't cast shadows
+	scene := scenes.Default()
+	scene.Lights[0].SetCastsShadows(false)
+	if result := shadowAt(scene, point)[0]; result != 0 {
+		t.Errorf("ShadowAt for a light without shadows expected 0, got %f", result)
+	}
+
+	// Objects that don't cast shadows
+	scene = scenes.Default()
+	for _, shape := range scene.Shapes {
+		shape.Material().CastsShadow = false
+	}
+	if result := shadowAt(scene, point)[0]; result != 0 {
+		t.Errorf("ShadowAt for objects without shadows expected 0, got %f", result)
+	}
+
+	// Only one of the objects casts a shadow
+	scene.Shapes[1].Material().CastsShadow = true
+	if result := shadowAt(scene, point)[0]; result != 1 {
+		t.Errorf("ShadowAt for an object with shadows expected 1, got %f", result)
+	}
+}
+
 func TestReflectedColor(t *testing.T) {
 	// The reflected color for a nonreflective material
 	scene := scenes.Default()
@@ -342,6 +436,19 @@ func TestReflectedColor(t *testing.T) {
 		t.Errorf("incorrect reflected color:\nresult: \n%s. \nexpected: \n%s", result, expected)
 	}
 
+	// A smooth metal reflects without being reflective, in its own color
+
+	mat = materials.DefaultMaterial()
+	mat.SetPattern(materials.NewPattern(materials.Base, color.New(1, 0.5, 0.5)))
+	mat.SetBSDF(materials.GGX{Metallic: 1, F0: 0.04})
+	shape.SetMaterial(mat)
+	result = reflectedColor(scene, comps)
+	expected = color.New(0.3806611962909404, 0.2384260679832546, 0.14305564078995273)
+
+	if !result.Equal(expected) {
+		t.Errorf("incorrect reflected color:\nresult: \n%s. \nexpected: \n%s", result, expected)
+	}
+
 	// Returns when ray has reached the maximum recursive depth.
 
 	scene = scenes.Default()
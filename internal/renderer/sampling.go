@@ -0,0 +1,188 @@
+package renderer
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+)
+
+// SamplerType determines where the samples are placed inside their strata.
+// A pixel is divided into a N×N grid of strata and every stratum gets one sample.
+type SamplerType int
+
+const (
+	Stratified SamplerType = iota // samples are placed in the center of their stratum.
+	Jittered                      // samples are placed randomly inside their stratum.
+)
+
+func (s SamplerType) String() string {
+	switch s {
+	case Stratified:
+		return "stratified"
+	case Jittered:
+		return "jittered"
+	default:
+		return fmt.Sprintf("SamplerType(%d)", int(s))
+	}
+}
+
+// ParseSampler converts the name of a sampler into a SamplerType.
+func ParseSampler(name string) (SamplerType, error) {
+	switch name {
+	case "", "stratified":
+		return Stratified, nil
+	case "jittered":
+		return Jittered, nil
+	default:
+		return Stratified, fmt.Errorf("unknown sampler: %s", name)
+	}
+}
+
+// pixel is the result of sampling a pixel.
+type pixel struct {
+	color   color.Color
+	passes  passes
+	samples int // the number of rays that were traced.
+}
+
+// Computes the color of the pixel (x, y) from samples×samples rays.
+// With a single sample the ray goes through the center of the pixel.
+func samplePixel(c *camera.Camera, w *scenes.Scene, x, y int, opts Options) pixel {
+	acc := newAccumulator(opts)
+	if opts.Samples <= 1 {
+		acc.trace(c, w, x, y, 0, 0, 1)
+		return acc.pixel(c, w, x, y)
+	}
+
+	filter := pixelFilter(opts)
+	radius := filter.Radius()
+	n := opts.Samples
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			u, v := opts.Sampler.offset(i, n), opts.Sampler.offset(j, n)
+			// map the stratum from [0, 1] onto the filter's footprint around the pixel center.
+			dx, dy := (2*u-1)*radius, (2*v-1)*radius
+			acc.add(c, w, x, y, dx, dy, filter)
+		}
+	}
+
+	return acc.pixel(c, w, x, y)
+}
+
+// Returns the color seen through the point (px, py) of the canvas, and the passes when they are requested.
+// With depth of field every ray starts from a random point of the lens,
+// the blur comes from averaging the samples of a pixel.
+// Points outside of the image of the projection are black.
+func cameraSample(c *camera.Camera, w *scenes.Scene, integrator Integrator, px, py float64, withPasses bool) (color.Color, passes) {
+	var r *ray.Ray
+	if c.Aperture > 0 {
+		r = c.RayThroughLens(px, py, rand.Float64(), rand.Float64())
+	} else {
+		r = c.RayAt(px, py)
+	}
+	if r == nil {
+		return color.Black(), backgroundPasses()
+	}
+	if withPasses {
+		return passesAt(w, integrator, r)
+	}
+	return radiance(w, integrator, r), passes{}
+}
+
+func pixelFilter(opts Options) Filter {
+	if opts.Filter == nil {
+		return BoxFilter{}
+	}
+	return opts.Filter
+}
+
+func pixelIntegrator(opts Options) Integrator {
+	if opts.Integrator == nil {
+		return Whitted{}
+	}
+	return opts.Integrator
+}
+
+// accumulator collects the filtered samples of a pixel.
+type accumulator struct {
+	integrator Integrator
+	withPasses bool // the passes are only computed when they are requested.
+
+	sum     color.Color
+	passes  passes  // the weighted sum of the filtered passes, the sample closest to the center for the rest.
+	nearest float64 // the squared distance of the sample closest to the center.
+	weights float64
+	count   int // the number of rays that were traced.
+}
+
+func newAccumulator(opts Options) accumulator {
+	return accumulator{integrator: pixelIntegrator(opts), withPasses: len(opts.Passes) > 0}
+}
+
+// traces a ray through the point at (dx, dy) from the center of the pixel (x, y) and adds it to the sum.
+// Samples with zero weight are skipped without tracing, in that case ok is false.
+func (a *accumulator) add(c *camera.Camera, w *scenes.Scene, x, y int, dx, dy float64, filter Filter) (col color.Color, ok bool) {
+	weight := filter.Weight(dx, dy)
+	if weight == 0 {
+		return color.Black(), false
+	}
+
+	return a.trace(c, w, x, y, dx, dy, weight), true
+}
+
+// traces a ray through the point at (dx, dy) from the center of the pixel (x, y) and adds it to the sum with the given weight.
+func (a *accumulator) trace(c *camera.Camera, w *scenes.Scene, x, y int, dx, dy, weight float64) color.Color {
+	col, p := cameraSample(c, w, a.integrator, float64(x)+0.5+dx, float64(y)+0.5+dy, a.withPasses)
+	a.sum = color.Add(a.sum, col.Scalar(weight))
+
+	if a.withPasses {
+		distance := dx*dx + dy*dy
+		closest := a.count == 0 || distance < a.nearest
+		for i := Pass(0); i < passCount; i++ {
+			if i.filtered() {
+				a.passes[i] = color.Add(a.passes[i], p[i].Scalar(weight))
+			} else if closest {
+				a.passes[i] = p[i]
+			}
+		}
+		if closest {
+			a.nearest = distance
+		}
+	}
+
+	a.weights += weight
+	a.count++
+	return col
+}
+
+// Returns the filtered pixel (x, y). When the filter gave no weight to the samples
+// the ray through the center of the pixel is used instead.
+func (a *accumulator) pixel(c *camera.Camera, w *scenes.Scene, x, y int) pixel {
+	if a.weights == 0 {
+		*a = accumulator{integrator: a.integrator, withPasses: a.withPasses, count: a.count}
+		a.trace(c, w, x, y, 0, 0, 1)
+	}
+
+	result := pixel{color: a.sum.Scalar(1 / a.weights), samples: a.count}
+	for i := Pass(0); i < passCount; i++ {
+		if i.filtered() {
+			result.passes[i] = a.passes[i].Scalar(1 / a.weights)
+		} else {
+			result.passes[i] = a.passes[i]
+		}
+	}
+	return result
+}
+
+// returns the position of a sample inside the i-th of n strata, between 0 and 1.
+func (s SamplerType) offset(i, n int) float64 {
+	if s == Jittered {
+		return (float64(i) + rand.Float64()) / float64(n)
+	}
+	return (float64(i) + 0.5) / float64(n)
+}
@@ -0,0 +1,97 @@
+package renderer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestSamplerOffset(t *testing.T) {
+	// Stratified samples are in the center of their stratum
+	expected := []float64{0.125, 0.375, 0.625, 0.875}
+	for i, e := range expected {
+		if result := Stratified.offset(i, 4); !utils.FloatEquals(result, e) {
+			t.Errorf("Stratified.offset(%d, 4) expected %f, got %f", i, e, result)
+		}
+	}
+
+	// Jittered samples stay inside their stratum
+	for i := 0; i < 4; i++ {
+		for k := 0; k < 100; k++ {
+			if result := Jittered.offset(i, 4); result < float64(i)/4 || result >= float64(i+1)/4 {
+				t.Errorf("Jittered.offset(%d, 4) outside of its stratum: %f", i, result)
+			}
+		}
+	}
+}
+
+func TestParseSampler(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected SamplerType
+		err      bool
+	}{
+		{name: "", expected: Stratified},
+		{name: "stratified", expected: Stratified},
+		{name: "jittered", expected: Jittered},
+		{name: "halton", err: true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseSampler(test.name)
+		if test.err && err == nil {
+			t.Errorf("ParseSampler(%s) expected an error", test.name)
+		}
+		if !test.err && result != test.expected {
+			t.Errorf("ParseSampler(%s) expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestSamplePixel(t *testing.T) {
+	scene := scenes.Default()
+	c := camera.New(11, 11, math.Pi/2)
+	c.SetTransform(camera.ViewTransformation(
+		tuple.NewPoint(0, 0, -5),
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewVector(0, 1, 0),
+	))
+
+	// A single sample is the same as a ray through the pixel center
+	single := samplePixel(c, scene, 5, 5, Options{Samples: 1}).color
+	expected := color.New(0.38066119308103435, 0.47582649135129296, 0.28549589481077575)
+	if !single.Equal(expected) {
+		t.Errorf("samplePixel expected %s, got %s", expected, single)
+	}
+
+	// A pixel on the edge of a flat colored sphere is a mix of the sphere and the background
+	for _, shape := range scene.Shapes {
+		mat := shape.Material()
+		mat.Ambient, mat.Diffuse, mat.Specular = 1, 0, 0
+	}
+	flat := samplePixel(c, scene, 5, 5, Options{Samples: 1}).color
+	edge := samplePixel(c, scene, 6, 5, Options{Samples: 1}).color
+	if !edge.Equal(flat) {
+		t.Fatalf("samplePixel expected the center of the edge pixel to hit the sphere, got %s", edge)
+	}
+	for _, filter := range []Filter{BoxFilter{}, TentFilter{}, GaussianFilter{Alpha: 2}} {
+		for _, sampler := range []SamplerType{Stratified, Jittered} {
+			result := samplePixel(c, scene, 6, 5, Options{Samples: 4, Sampler: sampler, Filter: filter}).color
+			if result.G <= 0 || result.G >= flat.G {
+				t.Errorf("samplePixel(%T, %s) expected an anti-aliased edge, got %s", filter, sampler, result)
+			}
+		}
+	}
+
+	// Stratified sampling is deterministic
+	a := samplePixel(c, scene, 6, 5, Options{Samples: 3, Filter: MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}}).color
+	b := samplePixel(c, scene, 6, 5, Options{Samples: 3, Filter: MitchellFilter{B: 1.0 / 3.0, C: 1.0 / 3.0}}).color
+	if !a.Equal(b) || a.Equal(flat) {
+		t.Errorf("samplePixel expected the same anti-aliased color twice, got %s and %s", a, b)
+	}
+}
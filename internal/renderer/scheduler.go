@@ -0,0 +1,191 @@
+package renderer
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+)
+
+// TileOrder determines the order in which the tiles of the image are handed to the workers.
+type TileOrder int
+
+const (
+	Scanline TileOrder = iota // row by row, from the top left corner.
+	Spiral                    // from the center of the image outwards.
+	Hilbert                   // along a Hilbert curve, keeps consecutive tiles close to each other.
+)
+
+// DefaultTileSize is the width and height of a tile in pixels.
+const DefaultTileSize = 32
+
+// Options controls how the image is split up and rendered.
+type Options struct {
+	Workers  int       // The number of goroutines rendering tiles, defaults to GOMAXPROCS.
+	TileSize int       // The width and height of a tile in pixels.
+	Order    TileOrder // The order in which the tiles are rendered.
+	Observer Observer  // Notified about the progress of the render, optional.
+
+	Samples int         // Each pixel is sampled by Samples×Samples rays, 1 shoots a single ray through the center.
+	Sampler SamplerType // Where the samples are placed inside the pixel.
+	Filter  Filter      // Reconstructs the pixel from its samples, defaults to the box filter.
+
+	Adaptive Adaptive // Refines only the noisy pixels, replaces Samples when enabled.
+
+	Integrator Integrator // Computes the light of the camera rays, defaults to Whitted.
+
+	Passes []Pass // The additional buffers rendered alongside the image, collected in Frame.Passes.
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Workers:    runtime.GOMAXPROCS(0),
+		TileSize:   DefaultTileSize,
+		Order:      Scanline,
+		Samples:    1,
+		Sampler:    Stratified,
+		Filter:     BoxFilter{},
+		Integrator: Whitted{},
+	}
+}
+
+func (o TileOrder) String() string {
+	switch o {
+	case Scanline:
+		return "scanline"
+	case Spiral:
+		return "spiral"
+	case Hilbert:
+		return "hilbert"
+	default:
+		return fmt.Sprintf("TileOrder(%d)", int(o))
+	}
+}
+
+// ParseTileOrder converts the name of a tile order into a TileOrder.
+func ParseTileOrder(name string) (TileOrder, error) {
+	switch name {
+	case "", "scanline":
+		return Scanline, nil
+	case "spiral":
+		return Spiral, nil
+	case "hilbert":
+		return Hilbert, nil
+	default:
+		return Scanline, fmt.Errorf("unknown tile order: %s", name)
+	}
+}
+
+// A rectangular area of the image, x0 and y0 are inclusive, x1 and y1 are exclusive.
+type tile struct {
+	x0, y0, x1, y1 int
+}
+
+func (t tile) pixels() int {
+	return (t.x1 - t.x0) * (t.y1 - t.y0)
+}
+
+// splits the image into tiles of the given size and orders them.
+// Tiles on the right and bottom edge are cropped to fit the image.
+func tiles(width, height, size int, order TileOrder) []tile {
+	if size < 1 {
+		size = DefaultTileSize
+	}
+	cols := (width + size - 1) / size
+	rows := (height + size - 1) / size
+
+	var cells [][2]int
+	switch order {
+	case Spiral:
+		cells = spiralCells(cols, rows)
+	case Hilbert:
+		cells = hilbertCells(cols, rows)
+	default:
+		cells = scanlineCells(cols, rows)
+	}
+
+	result := make([]tile, len(cells))
+	for i, cell := range cells {
+		x0, y0 := cell[0]*size, cell[1]*size
+		result[i] = tile{
+			x0: x0,
+			y0: y0,
+			x1: min(x0+size, width),
+			y1: min(y0+size, height),
+		}
+	}
+	return result
+}
+
+func scanlineCells(cols, rows int) [][2]int {
+	cells := make([][2]int, 0, cols*rows)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			cells = append(cells, [2]int{x, y})
+		}
+	}
+	return cells
+}
+
+// walks a square spiral starting at the center cell, skipping the cells that fall outside the grid.
+func spiralCells(cols, rows int) [][2]int {
+	total := cols * rows
+	cells := make([][2]int, 0, total)
+	x, y := (cols-1)/2, (rows-1)/2
+	// right, down, left, up
+	directions := [4][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}}
+
+	for step, dir := 1, 0; len(cells) < total; step++ {
+		// every step length is walked twice before it grows.
+		for turn := 0; turn < 2; turn++ {
+			for i := 0; i < step && len(cells) < total; i++ {
+				if 0 <= x && x < cols && 0 <= y && y < rows {
+					cells = append(cells, [2]int{x, y})
+				}
+				x += directions[dir][0]
+				y += directions[dir][1]
+			}
+			dir = (dir + 1) % 4
+		}
+	}
+	return cells
+}
+
+// orders the cells by their distance along a Hilbert curve that covers the grid.
+func hilbertCells(cols, rows int) [][2]int {
+	n := 1
+	for n < cols || n < rows {
+		n *= 2
+	}
+
+	cells := scanlineCells(cols, rows)
+	sort.SliceStable(cells, func(i, j int) bool {
+		return hilbertIndex(n, cells[i][0], cells[i][1]) < hilbertIndex(n, cells[j][0], cells[j][1])
+	})
+	return cells
+}
+
+// converts a cell position into its distance along the Hilbert curve filling an n by n grid.
+// n must be a power of 2.
+func hilbertIndex(n, x, y int) int {
+	d := 0
+	for s := n / 2; s > 0; s /= 2 {
+		rx, ry := 0, 0
+		if x&s > 0 {
+			rx = 1
+		}
+		if y&s > 0 {
+			ry = 1
+		}
+		d += s * s * ((3 * rx) ^ ry)
+
+		// rotate the quadrant so the curve stays continuous.
+		if ry == 0 {
+			if rx == 1 {
+				x = n - 1 - x
+				y = n - 1 - y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
@@ -0,0 +1,127 @@
+package renderer
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/camera"
+	"github.com/kaizencodes/glimpse/internal/scenes"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+func TestTilesCoverImage(t *testing.T) {
+	var tests = []struct {
+		width, height, size int
+		order               TileOrder
+		expectedTiles       int
+	}{
+		{width: 64, height: 64, size: 32, order: Scanline, expectedTiles: 4},
+		{width: 100, height: 37, size: 16, order: Scanline, expectedTiles: 21},
+		{width: 100, height: 37, size: 16, order: Spiral, expectedTiles: 21},
+		{width: 100, height: 37, size: 16, order: Hilbert, expectedTiles: 21},
+		{width: 5, height: 300, size: 7, order: Spiral, expectedTiles: 43},
+		{width: 5, height: 300, size: 7, order: Hilbert, expectedTiles: 43},
+	}
+
+	for _, test := range tests {
+		result := tiles(test.width, test.height, test.size, test.order)
+		if len(result) != test.expectedTiles {
+			t.Errorf("tiles(%d, %d, %d, %s) expected %d tiles, got %d", test.width, test.height, test.size, test.order, test.expectedTiles, len(result))
+		}
+
+		// every pixel has to be covered by exactly one tile.
+		coverage := make([]int, test.width*test.height)
+		for _, tl := range result {
+			for y := tl.y0; y < tl.y1; y++ {
+				for x := tl.x0; x < tl.x1; x++ {
+					coverage[y*test.width+x]++
+				}
+			}
+		}
+		for i, count := range coverage {
+			if count != 1 {
+				t.Errorf("tiles(%d, %d, %d, %s) pixel (%d, %d) covered %d times", test.width, test.height, test.size, test.order, i%test.width, i/test.width, count)
+				break
+			}
+		}
+	}
+}
+
+func TestTileOrder(t *testing.T) {
+	// Scanline goes row by row
+	result := tiles(4, 4, 2, Scanline)
+	expected := []tile{{0, 0, 2, 2}, {2, 0, 4, 2}, {0, 2, 2, 4}, {2, 2, 4, 4}}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("scanline tile %d expected %v, got %v", i, expected[i], result[i])
+		}
+	}
+
+	// Spiral starts in the center
+	result = tiles(50, 50, 10, Spiral)
+	if result[0] != (tile{20, 20, 30, 30}) {
+		t.Errorf("spiral should start at the center tile, got %v", result[0])
+	}
+
+	// Consecutive tiles on a Hilbert curve are always neighbours
+	result = tiles(80, 80, 10, Hilbert)
+	for i := 1; i < len(result); i++ {
+		dx := math.Abs(float64(result[i].x0 - result[i-1].x0))
+		dy := math.Abs(float64(result[i].y0 - result[i-1].y0))
+		if dx+dy != 10 {
+			t.Errorf("hilbert tiles %v and %v are not adjacent", result[i-1], result[i])
+		}
+	}
+}
+
+func TestParseTileOrder(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected TileOrder
+		err      bool
+	}{
+		{name: "", expected: Scanline},
+		{name: "scanline", expected: Scanline},
+		{name: "spiral", expected: Spiral},
+		{name: "hilbert", expected: Hilbert},
+		{name: "zigzag", err: true},
+	}
+
+	for _, test := range tests {
+		result, err := ParseTileOrder(test.name)
+		if test.err && err == nil {
+			t.Errorf("ParseTileOrder(%s) expected an error", test.name)
+		}
+		if !test.err && result != test.expected {
+			t.Errorf("ParseTileOrder(%s) expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	// The result does not depend on the number of workers or the tile order.
+	scene := scenes.Default()
+	c := camera.New(21, 13, math.Pi/2)
+	c.SetTransform(camera.ViewTransformation(
+		tuple.NewPoint(0, 0, -5),
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewVector(0, 1, 0),
+	))
+	expected, _ := RenderContext(context.Background(), c, scene, Options{Workers: 1, TileSize: 64, Order: Scanline})
+
+	for _, opts := range []Options{
+		{Workers: 4, TileSize: 4, Order: Scanline},
+		{Workers: 3, TileSize: 5, Order: Spiral},
+		{Workers: 8, TileSize: 3, Order: Hilbert},
+	} {
+		result, _ := RenderContext(context.Background(), c, scene, opts)
+		for x := 0; x < c.Width; x++ {
+			for y := 0; y < c.Height; y++ {
+				if !result[x][y].Equal(expected[x][y]) {
+					t.Errorf("RenderContext(%v) pixel (%d, %d) expected %s, got %s", opts, x, y, expected[x][y], result[x][y])
+				}
+			}
+		}
+	}
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/kaizencodes/glimpse/internal/light"
 	"github.com/kaizencodes/glimpse/internal/materials"
 	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/postprocess"
 	"github.com/kaizencodes/glimpse/internal/projectpath"
+	"github.com/kaizencodes/glimpse/internal/renderer"
 	"github.com/kaizencodes/glimpse/internal/scenes"
 	cfg "github.com/kaizencodes/glimpse/internal/scenes/config"
+	"github.com/kaizencodes/glimpse/internal/sdf"
 	"github.com/kaizencodes/glimpse/internal/shapes"
 	"github.com/kaizencodes/glimpse/internal/tuple"
 )
@@ -21,10 +24,32 @@ func BuildScene(config cfg.Scene) (*camera.Camera, *scenes.Scene) {
 	scene := scenes.Default()
 	scene.Lights = buildLights(config.Lights)
 	scene.Shapes = buildObjects(config.Objects)
+	scene.Lights = append(scene.Lights, buildMeshLights(config.Objects, scene.Shapes)...)
 
 	return cam, scene
 }
 
+// Emissive models light up the scene, their triangles become lights.
+// The objects are built in the order of the config, so the two can be walked together.
+// The models of CSG shapes light with their whole surface, the parts cut away included.
+func buildMeshLights(config []cfg.Object, built []shapes.Shape) []light.Light {
+	var lights []light.Light
+	for i := 0; i < len(config); i++ {
+		switch shape := built[i].(type) {
+		case *shapes.Model:
+			if shape.Material().Emission != color.Black() {
+				lights = append(lights, light.NewMeshLight(shape.Triangles(), int(config[i].LightSamples)))
+			}
+		case *shapes.Group:
+			lights = append(lights, buildMeshLights(config[i].Children, shape.Children())...)
+		case *shapes.CSG:
+			operands := []cfg.Object{*config[i].Left, *config[i].Right}
+			lights = append(lights, buildMeshLights(operands, []shapes.Shape{shape.Left(), shape.Right()})...)
+		}
+	}
+	return lights
+}
+
 func buildCamera(config cfg.Camera) *camera.Camera {
 	cam := camera.New(
 		int(config.Width),
@@ -36,21 +61,167 @@ func buildCamera(config cfg.Camera) *camera.Camera {
 		tuple.NewVectorFromSlice(config.To),
 		tuple.NewVectorFromSlice(config.Up),
 	))
+
+	projection, err := camera.ParseProjection(config.Type, config.ViewWidth)
+	if err != nil {
+		panic(err.Error())
+	}
+	cam.SetProjection(projection)
+
+	cam.Aperture = config.Aperture
+	cam.FocalDistance = config.FocalDistance
+	if cam.FocalDistance == 0 {
+		// by default the point the camera is looking at is in focus.
+		cam.FocalDistance = tuple.Subtract(
+			tuple.NewPointFromSlice(config.To),
+			tuple.NewPointFromSlice(config.From),
+		).Magnitude()
+	}
 	return cam
 }
 
+// BuildRenderOptions converts the render section of the scene into renderer options.
+// The settings that are not part of the scene, like the number of workers, are left at their defaults.
+func BuildRenderOptions(config cfg.Render) renderer.Options {
+	opts := renderer.DefaultOptions()
+	if config.Samples > 0 {
+		opts.Samples = int(config.Samples)
+	}
+
+	sampler, err := renderer.ParseSampler(config.Sampler)
+	if err != nil {
+		panic(err.Error())
+	}
+	opts.Sampler = sampler
+
+	filter, err := renderer.ParseFilter(config.Filter)
+	if err != nil {
+		panic(err.Error())
+	}
+	opts.Filter = filter
+
+	opts.Adaptive = renderer.Adaptive{
+		MinSamples: int(config.Adaptive.MinSamples),
+		MaxSamples: int(config.Adaptive.MaxSamples),
+		Threshold:  config.Adaptive.Threshold,
+	}
+
+	opts.Integrator = buildIntegrator(config)
+
+	for _, name := range config.Passes {
+		pass, err := renderer.ParsePass(name)
+		if err != nil {
+			panic(err.Error())
+		}
+		opts.Passes = append(opts.Passes, pass)
+	}
+
+	return opts
+}
+
+// BuildPostProcess converts the post section of the scene into a post-processing pipeline.
+func BuildPostProcess(config cfg.Post) postprocess.Pipeline {
+	toneMapper, err := postprocess.ParseToneMapper(config.Tonemap)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return postprocess.Pipeline{
+		Exposure:   config.Exposure,
+		ToneMapper: toneMapper,
+		SRGB:       config.SRGB,
+	}
+}
+
+func buildIntegrator(config cfg.Render) renderer.Integrator {
+	switch config.Integrator {
+	case "", "whitted":
+		return renderer.Whitted{}
+	case "path":
+		tracer := renderer.NewPathTracer()
+		if config.MaxDepth > 0 {
+			tracer.MaxDepth = int(config.MaxDepth)
+		}
+		if config.RouletteDepth > 0 {
+			tracer.RouletteDepth = int(config.RouletteDepth)
+		}
+		return tracer
+	default:
+		panic("Unknown integrator")
+	}
+}
+
 func buildLights(config []cfg.Light) []light.Light {
 	var lights []light.Light
 	for i := 0; i < len(config); i++ {
-		l := light.NewLight(
-			tuple.NewPointFromSlice(config[i].Position),
-			color.FromSlice(config[i].Intensity),
-		)
+		l := buildLight(config[i])
+		l.SetAttenuation(buildAttenuation(config[i].Attenuation))
+		if config[i].CastsShadows != nil {
+			l.SetCastsShadows(*config[i].CastsShadows)
+		}
 		lights = append(lights, l)
 	}
 	return lights
 }
 
+func buildLight(config cfg.Light) light.Light {
+	intensity := color.FromSlice(config.Intensity)
+	if config.Type == "directional" {
+		return light.NewDirectionalLight(tuple.NewVectorFromSlice(config.Direction), intensity)
+	}
+
+	// every other light has a position.
+	position := tuple.NewPointFromSlice(config.Position)
+
+	switch config.Type {
+	case "", "point":
+		return light.NewLight(position, intensity)
+	case "rect":
+		return light.NewRectLight(
+			position,
+			tuple.NewVectorFromSlice(config.U),
+			tuple.NewVectorFromSlice(config.V),
+			intensity,
+			int(config.Samples),
+		)
+	case "disk":
+		return light.NewDiskLight(
+			position,
+			tuple.NewVectorFromSlice(config.Normal),
+			config.Radius,
+			intensity,
+			int(config.Samples),
+		)
+	case "spot":
+		return light.NewSpotLight(
+			position,
+			tuple.NewVectorFromSlice(config.Direction),
+			config.Inner,
+			config.Outer,
+			intensity,
+		)
+	default:
+		panic("Unknown light type")
+	}
+}
+
+func buildAttenuation(config cfg.Attenuation) light.Attenuation {
+	switch config.Type {
+	case "", "none":
+		return light.Attenuation{}
+	case "inverse-square":
+		return light.InverseSquare
+	case "polynomial":
+		return light.Attenuation{
+			Constant:  config.Constant,
+			Linear:    config.Linear,
+			Quadratic: config.Quadratic,
+		}
+	default:
+		panic("Unknown attenuation type")
+	}
+}
+
 func buildObjects(config []cfg.Object) []shapes.Shape {
 	var shapes []shapes.Shape
 	for i := 0; i < len(config); i++ {
@@ -87,6 +258,58 @@ func buildObject(config cfg.Object) shapes.Shape {
 
 		shape = cylinder
 
+		shape.SetMaterial(buildMaterial(config.Material))
+		shape.SetTransform(buildTransforms(config.Transform))
+	case "cone":
+		cone := shapes.NewCone()
+
+		cone.Minimum = config.Minimum
+		cone.Maximum = config.Maximum
+		cone.Closed = config.Closed
+
+		shape = cone
+
+		shape.SetMaterial(buildMaterial(config.Material))
+		shape.SetTransform(buildTransforms(config.Transform))
+	case "torus":
+		torus := shapes.NewTorus()
+
+		if config.MajorRadius != 0 {
+			torus.MajorRadius = config.MajorRadius
+		}
+		if config.MinorRadius != 0 {
+			torus.MinorRadius = config.MinorRadius
+		}
+
+		shape = torus
+
+		shape.SetMaterial(buildMaterial(config.Material))
+		shape.SetTransform(buildTransforms(config.Transform))
+	case "disk":
+		disk := shapes.NewDisk()
+
+		disk.InnerRadius = config.InnerRadius
+
+		shape = disk
+
+		shape.SetMaterial(buildMaterial(config.Material))
+		shape.SetTransform(buildTransforms(config.Transform))
+	case "quad":
+		shape = shapes.NewQuad()
+
+		shape.SetMaterial(buildMaterial(config.Material))
+		shape.SetTransform(buildTransforms(config.Transform))
+	case "sdf":
+		if config.SDF == nil {
+			panic("SDF shape without a distance function")
+		}
+		shape = shapes.NewSDF(buildSDF(*config.SDF))
+
+		shape.SetMaterial(buildMaterial(config.Material))
+		shape.SetTransform(buildTransforms(config.Transform))
+	case "heightfield":
+		shape = shapes.NewHeightfield(buildHeights(config))
+
 		shape.SetMaterial(buildMaterial(config.Material))
 		shape.SetTransform(buildTransforms(config.Transform))
 	case "model":
@@ -100,23 +323,75 @@ func buildObject(config cfg.Object) shapes.Shape {
 		model.SetTransform(buildTransforms(config.Transform))
 
 		model.CalculateBoundingBox()
+		model.BuildBVH(shapes.DefaultLeafSize)
 
 		shape = model
 	case "group":
 		group := shapes.NewGroup()
-		group.AddChild(buildObjects(config.Children)...)
+		group.AddChild(buildObjects(inheritShadowFlags(config))...)
 		group.SetTransform(buildTransforms(config.Transform))
 		group.CalculateBoundingBoxCascade()
 
-		group.Divide(10)
+		group.BuildBVH(shapes.DefaultLeafSize)
 		shape = group
+	case "csg":
+		operands := inheritShadowFlags(cfg.Object{
+			Children:       []cfg.Object{*config.Left, *config.Right},
+			CastsShadow:    config.CastsShadow,
+			ReceivesShadow: config.ReceivesShadow,
+		})
+		csg := shapes.NewCSG(buildOperation(config.Operation), buildObject(operands[0]), buildObject(operands[1]))
+		csg.SetTransform(buildTransforms(config.Transform))
+		csg.CalculateBoundingBox()
+
+		shape = csg
 	default:
 		panic("Unknown shape type")
 	}
 
+	// groups and CSG shapes don't have their own material, their children inherit the flags instead.
+	if config.Type != "group" && config.Type != "csg" {
+		mat := shape.Material()
+		if config.CastsShadow != nil {
+			mat.CastsShadow = *config.CastsShadow
+		}
+		if config.ReceivesShadow != nil {
+			mat.ReceivesShadow = *config.ReceivesShadow
+		}
+	}
+
 	return shape
 }
 
+func buildOperation(operation string) shapes.Operation {
+	switch operation {
+	case "union":
+		return shapes.CSGUnion
+	case "intersection":
+		return shapes.CSGIntersection
+	case "difference":
+		return shapes.CSGDifference
+	default:
+		panic("Unknown csg operation")
+	}
+}
+
+// Returns the children of a group, the shadow flags of the group are
+// applied to the children that don't set them.
+func inheritShadowFlags(config cfg.Object) []cfg.Object {
+	children := make([]cfg.Object, len(config.Children))
+	copy(children, config.Children)
+	for i := 0; i < len(children); i++ {
+		if children[i].CastsShadow == nil {
+			children[i].CastsShadow = config.CastsShadow
+		}
+		if children[i].ReceivesShadow == nil {
+			children[i].ReceivesShadow = config.ReceivesShadow
+		}
+	}
+	return children
+}
+
 func buildTransforms(config []cfg.Transform) matrix.Matrix {
 	var transforms matrix.Matrix
 
@@ -182,42 +457,320 @@ func buildMaterial(config cfg.Material) *materials.Material {
 		material.SetTransform(buildTransforms(config.Pattern.Transform))
 	}
 
+	if len(config.Emission) > 0 {
+		strength := config.EmissionStrength
+		if strength == 0 {
+			strength = 1
+		}
+		material.Emission = color.FromSlice(config.Emission).Scalar(strength)
+	}
+
+	if config.BSDF.Type != "" {
+		material.SetBSDF(buildBSDF(config.BSDF))
+	}
+
+	if config.Bump.Pattern.Type != "" {
+		material.Bump = buildBump(config.Bump)
+	}
+
 	return material
 }
 
+func buildBSDF(config cfg.BSDF) materials.BSDF {
+	switch config.Type {
+	case "phong":
+		return materials.Phong{}
+	case "ggx":
+		f0 := 0.04
+		if config.F0 != nil {
+			f0 = *config.F0
+		}
+		return materials.GGX{Roughness: config.Roughness, Metallic: config.Metallic, F0: f0}
+	default:
+		panic("Unknown bsdf type")
+	}
+}
+
+func buildBump(config cfg.Bump) *materials.Bump {
+	strength := config.Strength
+	if strength == 0 {
+		strength = 1
+	}
+
+	var normalMap bool
+	switch config.Type {
+	case "", "height":
+		normalMap = false
+	case "normal":
+		normalMap = true
+	default:
+		panic("Unknown bump type")
+	}
+
+	return &materials.Bump{
+		Pattern:   buildInputPattern(config.Pattern),
+		NormalMap: normalMap,
+		Strength:  strength,
+	}
+}
+
 func buildPattern(config cfg.Pattern) *materials.Pattern {
 	var pattern *materials.Pattern
 
 	switch config.Type {
 	case "stripe":
-		pattern = materials.NewPattern(
-			materials.Stripe,
-			color.FromSlice(config.Colors[0]),
-			color.FromSlice(config.Colors[1]),
-		)
+		pattern = buildTwoInputPattern(materials.Stripe, config)
 	case "gradient":
-		pattern = materials.NewPattern(
-			materials.Gradient,
-			color.FromSlice(config.Colors[0]),
-			color.FromSlice(config.Colors[1]),
-		)
+		pattern = buildTwoInputPattern(materials.Gradient, config)
 	case "ring":
-		pattern = materials.NewPattern(
-			materials.Ring,
-			color.FromSlice(config.Colors[0]),
-			color.FromSlice(config.Colors[1]),
-		)
+		pattern = buildTwoInputPattern(materials.Ring, config)
 	case "checker":
-		pattern = materials.NewPattern(
-			materials.Checker,
+		pattern = buildTwoInputPattern(materials.Checker, config)
+	case "uv_checker":
+		pattern = materials.NewUVCheckerPattern(
+			config.Width,
+			config.Height,
 			color.FromSlice(config.Colors[0]),
 			color.FromSlice(config.Colors[1]),
+			buildMapping(config.Mapping),
 		)
+	case "texture":
+		pattern = materials.NewTexturePattern(buildTexture(config), buildMapping(config.Mapping))
+	case "blend":
+		a, b := buildInputPatterns(config)
+		pattern = materials.NewBlendPattern(a, b, config.Weight)
+	case "mask":
+		a, b := buildInputPatterns(config)
+		if config.Mask == nil {
+			panic("Mask pattern needs a mask")
+		}
+		pattern = materials.NewMaskPattern(a, b, buildInputPattern(*config.Mask))
+	case "add":
+		a, b := buildInputPatterns(config)
+		pattern = materials.NewOperatorPattern(materials.Add, a, b)
+	case "subtract":
+		a, b := buildInputPatterns(config)
+		pattern = materials.NewOperatorPattern(materials.Subtract, a, b)
+	case "multiply":
+		a, b := buildInputPatterns(config)
+		pattern = materials.NewOperatorPattern(materials.Multiply, a, b)
+	case "min":
+		a, b := buildInputPatterns(config)
+		pattern = materials.NewOperatorPattern(materials.Min, a, b)
+	case "max":
+		a, b := buildInputPatterns(config)
+		pattern = materials.NewOperatorPattern(materials.Max, a, b)
+	case "clouds":
+		pattern = buildNoisePattern(materials.Clouds, config)
+	case "turbulence":
+		pattern = buildNoisePattern(materials.Turbulent, config)
+	case "marble":
+		pattern = buildNoisePattern(materials.Marble, config)
+	case "wood":
+		pattern = buildNoisePattern(materials.Wood, config)
 	default:
 		pattern = materials.NewPattern(
 			materials.Base,
 			color.FromSlice(config.Colors[0]),
 		)
 	}
+
+	if config.Perturb.Amount > 0 {
+		pattern = materials.NewPerturbedPattern(pattern, buildNoise(config.Perturb.Noise), config.Perturb.Amount)
+	}
 	return pattern
 }
+
+// Stripes, gradients, rings and checkers take either two colors or two patterns.
+func buildTwoInputPattern(kind materials.PatternType, config cfg.Pattern) *materials.Pattern {
+	if len(config.Patterns) > 0 {
+		a, b := buildInputPatterns(config)
+		return materials.NewNestedPattern(kind, a, b)
+	}
+	if len(config.Colors) < 2 {
+		panic(fmt.Sprintf("%s pattern needs two colors or two patterns", config.Type))
+	}
+	return materials.NewPattern(
+		kind,
+		color.FromSlice(config.Colors[0]),
+		color.FromSlice(config.Colors[1]),
+	)
+}
+
+func buildInputPatterns(config cfg.Pattern) (*materials.Pattern, *materials.Pattern) {
+	if len(config.Patterns) < 2 {
+		panic(fmt.Sprintf("%s pattern needs two patterns", config.Type))
+	}
+	return buildInputPattern(config.Patterns[0]), buildInputPattern(config.Patterns[1])
+}
+
+// The inputs of a pattern keep their own transform, the transform of the outermost pattern is set on the material.
+func buildInputPattern(config cfg.Pattern) *materials.Pattern {
+	pattern := buildPattern(config)
+	pattern.SetTransform(buildTransforms(config.Transform))
+	return pattern
+}
+
+func buildNoisePattern(kind materials.NoiseKind, config cfg.Pattern) *materials.Pattern {
+	return materials.NewNoisePattern(
+		kind,
+		buildNoise(config.Noise),
+		color.FromSlice(config.Colors[0]),
+		color.FromSlice(config.Colors[1]),
+	)
+}
+
+// The unset parameters default to 4 octaves of Perlin noise, doubling the frequency and halving the amplitude.
+func buildNoise(config cfg.Noise) *materials.Noise {
+	var basis materials.NoiseBasis
+	switch config.Type {
+	case "", "perlin":
+		basis = materials.PerlinNoise
+	case "simplex":
+		basis = materials.SimplexNoise
+	default:
+		panic("Unknown noise type")
+	}
+
+	octaves := int(config.Octaves)
+	if octaves == 0 {
+		octaves = 4
+	}
+	lacunarity := config.Lacunarity
+	if lacunarity == 0 {
+		lacunarity = 2
+	}
+	gain := config.Gain
+	if gain == 0 {
+		gain = 0.5
+	}
+
+	return materials.NewNoise(basis, octaves, lacunarity, gain, config.Seed)
+}
+
+func buildMapping(mapping string) materials.Mapping {
+	switch mapping {
+	case "":
+		return materials.AutoMapping
+	case "spherical":
+		return materials.SphericalMapping
+	case "planar":
+		return materials.PlanarMapping
+	case "cylindrical":
+		return materials.CylindricalMapping
+	case "cube":
+		return materials.CubeMapping
+	case "vertex":
+		return materials.VertexMapping
+	default:
+		panic("Unknown mapping")
+	}
+}
+
+func buildTexture(config cfg.Pattern) *materials.Texture {
+	file, err := os.Open(projectpath.Root + config.File)
+	if err != nil {
+		panic(fmt.Sprintf("Texture file could not be read: %s\n%s", config.File, err.Error()))
+	}
+	defer file.Close()
+
+	image, err := materials.ReadImage(file, config.SRGB)
+	if err != nil {
+		panic(fmt.Sprintf("Texture file could not be read: %s\n%s", config.File, err.Error()))
+	}
+
+	var filter materials.Filter
+	switch config.Filter {
+	case "", "nearest":
+		filter = materials.NearestFilter
+	case "bilinear":
+		filter = materials.BilinearFilter
+	default:
+		panic("Unknown texture filter")
+	}
+
+	var wrap materials.Wrap
+	switch config.Wrap {
+	case "", "repeat":
+		wrap = materials.RepeatWrap
+	case "clamp":
+		wrap = materials.ClampWrap
+	default:
+		panic("Unknown texture wrap")
+	}
+
+	return materials.NewTexture(image, filter, wrap)
+}
+
+// the heights come from the brightness of an image, or from noise.
+func buildHeights(config cfg.Object) [][]float64 {
+	if config.File != "" {
+		file, err := os.Open(projectpath.Root + config.File)
+		if err != nil {
+			panic(fmt.Sprintf("Heightfield file could not be read: %s\n%s", config.File, err.Error()))
+		}
+		defer file.Close()
+
+		image, err := materials.ReadImage(file, false)
+		if err != nil {
+			panic(fmt.Sprintf("Heightfield file could not be read: %s\n%s", config.File, err.Error()))
+		}
+		return shapes.HeightsFromImage(image)
+	}
+
+	if config.Noise == nil {
+		panic("Heightfield needs a file or noise")
+	}
+	resolution := int(config.Resolution)
+	if resolution == 0 {
+		resolution = 128
+	}
+	frequency := config.Frequency
+	if frequency == 0 {
+		frequency = 1
+	}
+	return shapes.HeightsFromNoise(buildNoise(*config.Noise), resolution, frequency)
+}
+
+func buildSDF(config cfg.SDF) sdf.Field {
+	var field sdf.Field
+	switch config.Type {
+	case "sphere":
+		field = sdf.Sphere(config.Radius)
+	case "rounded_box":
+		field = sdf.RoundedBox(tuple.NewVectorFromSlice(config.Size), config.Radius)
+	case "capsule":
+		field = sdf.Capsule(tuple.NewPointFromSlice(config.From), tuple.NewPointFromSlice(config.To), config.Radius)
+	case "torus":
+		field = sdf.Torus(config.MajorRadius, config.MinorRadius)
+	case "union":
+		field = buildSDFOperation(config, sdf.Union)
+	case "subtract":
+		field = buildSDFOperation(config, sdf.Subtract)
+	case "intersection":
+		field = buildSDFOperation(config, sdf.Intersection)
+	default:
+		panic("Unknown sdf type")
+	}
+
+	if len(config.Offset) > 0 {
+		field = sdf.Translate(field, tuple.NewVectorFromSlice(config.Offset))
+	}
+	if len(config.Repeat) > 0 {
+		field = sdf.Repeat(field, tuple.NewVectorFromSlice(config.Repeat))
+	}
+	return field
+}
+
+// folds the children with the operation, subtract carves all the others out of the first child.
+func buildSDFOperation(config cfg.SDF, operation func(a, b sdf.Field, smoothness float64) sdf.Field) sdf.Field {
+	if len(config.Children) < 2 {
+		panic(fmt.Sprintf("SDF %s needs at least two children", config.Type))
+	}
+
+	field := buildSDF(config.Children[0])
+	for _, child := range config.Children[1:] {
+		field = operation(field, buildSDF(child), config.Smoothness)
+	}
+	return field
+}
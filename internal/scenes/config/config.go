@@ -2,22 +2,70 @@ package config
 
 type Scene struct {
 	Camera  Camera
+	Render  Render
+	Post    Post
 	Lights  []Light
 	Objects []Object
 }
 
 type Camera struct {
+	Type   string
 	Width  int64
 	Height int64
 	Fov    float64
 	From   []float64
 	To     []float64
 	Up     []float64
+
+	Aperture      float64
+	FocalDistance float64 `yaml:"focal_distance"`
+	ViewWidth     float64 `yaml:"view_width"`
+}
+
+type Render struct {
+	Samples  int64
+	Sampler  string
+	Filter   string
+	Adaptive Adaptive
+	Passes   []string
+
+	Integrator    string
+	MaxDepth      int64 `yaml:"max_depth"`
+	RouletteDepth int64 `yaml:"roulette_depth"`
+}
+
+type Adaptive struct {
+	MinSamples int64 `yaml:"min_samples"`
+	MaxSamples int64 `yaml:"max_samples"`
+	Threshold  float64
+	Heatmap    bool
+}
+
+type Post struct {
+	Exposure float64
+	Tonemap  string
+	SRGB     bool `yaml:"srgb"`
 }
 
 type Light struct {
+	Type      string
 	Position  []float64
 	Intensity []float64
+	U, V      []float64
+	Normal    []float64
+	Radius    float64
+	Samples   int64
+	Direction []float64
+	Inner     float64 `yaml:"inner_angle"`
+	Outer     float64 `yaml:"outer_angle"`
+
+	Attenuation  Attenuation
+	CastsShadows *bool `yaml:"casts_shadows"` // nil when not set, lights cast shadows by default.
+}
+
+type Attenuation struct {
+	Type                        string
+	Constant, Linear, Quadratic float64
 }
 
 type Object struct {
@@ -26,8 +74,20 @@ type Object struct {
 	Material         Material
 	Minimum, Maximum float64
 	Closed           bool
+	MajorRadius      float64 `yaml:"major_radius"`
+	MinorRadius      float64 `yaml:"minor_radius"`
+	InnerRadius      float64 `yaml:"inner_radius"`
 	File             string
 	Children         []Object
+	CastsShadow      *bool `yaml:"casts_shadow"`    // nil when not set, inherited from the parent group.
+	ReceivesShadow   *bool `yaml:"receives_shadow"` // nil when not set, inherited from the parent group.
+	LightSamples     int64 `yaml:"light_samples"`
+	Operation        string
+	Left, Right      *Object // the operands of a CSG shape.
+	SDF              *SDF    // the distance function of an sdf shape.
+	Noise            *Noise  // the heights of a heightfield without a file.
+	Resolution       int64
+	Frequency        float64
 }
 
 type Transform struct {
@@ -40,10 +100,60 @@ type Material struct {
 	Pattern                                                         Pattern
 	Ambient, Diffuse, Specular, Shininess, Reflective, Transparency float64
 	RefractiveIndex                                                 float64 `yaml:"refractive_index"`
+	Emission                                                        []float64
+	EmissionStrength                                                float64 `yaml:"emission_strength"`
+	BSDF                                                            BSDF
+	Bump                                                            Bump
+}
+
+type Bump struct {
+	Type     string
+	Strength float64
+	Pattern  Pattern
+}
+
+type BSDF struct {
+	Type                string
+	Roughness, Metallic float64
+	F0                  *float64 // nil when not set, 0.04 by default.
 }
 
 type Pattern struct {
-	Type      string
-	Colors    [][]float64
-	Transform []Transform
+	Type          string
+	Colors        [][]float64
+	Transform     []Transform
+	Mapping       string
+	File          string
+	Filter, Wrap  string
+	SRGB          bool `yaml:"srgb"`
+	Width, Height float64
+	Noise         Noise
+	Perturb       Perturb
+	Patterns      []Pattern // the inputs of patterns made of other patterns.
+	Mask          *Pattern
+	Weight        float64
+}
+
+type Noise struct {
+	Type             string
+	Octaves          int64
+	Lacunarity, Gain float64
+	Seed             uint64
+}
+
+type Perturb struct {
+	Amount float64
+	Noise  Noise
+}
+
+type SDF struct {
+	Type           string
+	Radius         float64
+	Size           []float64
+	From, To       []float64
+	MajorRadius    float64 `yaml:"major_radius"`
+	MinorRadius    float64 `yaml:"minor_radius"`
+	Children       []SDF   // the operands of union, subtract and intersection.
+	Smoothness     float64
+	Offset, Repeat []float64
 }
@@ -10,20 +10,84 @@ import (
 
 func TestRead(t *testing.T) {
 	config, err := Read(projectpath.Root + `/internal/scenes/reader/examples/test_valid.yml`)
+	yes, no := true, false
 	expectedConfig := cfg.Scene{
 		Camera: cfg.Camera{
+			Type:   "perspective",
 			Width:  250,
 			Height: 125,
 			Fov:    1.0471975512,
 			From:   []float64{0, 2, -7},
 			To:     []float64{0, 1, 0},
 			Up:     []float64{0, 1, 0},
+
+			Aperture:      0.1,
+			FocalDistance: 7.5,
+			ViewWidth:     12,
+		},
+		Render: cfg.Render{
+			Samples: 2,
+			Sampler: "jittered",
+			Filter:  "mitchell",
+			Adaptive: cfg.Adaptive{
+				MinSamples: 4,
+				MaxSamples: 32,
+				Threshold:  0.01,
+				Heatmap:    true,
+			},
+			Integrator:    "path",
+			MaxDepth:      6,
+			RouletteDepth: 2,
+			Passes:        []string{"depth", "normal", "object_id", "direct"},
+		},
+		Post: cfg.Post{
+			Exposure: 0.5,
+			Tonemap:  "aces",
+			SRGB:     true,
 		},
 		Lights: []cfg.Light{
 			{
 				Position:  []float64{-10, 10, -10},
 				Intensity: []float64{1, 1, 1},
 			},
+			{
+				Type:      "rect",
+				Position:  []float64{0, 8, 0},
+				Intensity: []float64{0.5, 0.5, 0.5},
+				U:         []float64{2, 0, 0},
+				V:         []float64{0, 0, 2},
+				Samples:   3,
+				Attenuation: cfg.Attenuation{
+					Type: "inverse-square",
+				},
+			},
+			{
+				Type:      "disk",
+				Position:  []float64{5, 5, -5},
+				Intensity: []float64{0.2, 0.2, 0.2},
+				Normal:    []float64{-1, -1, 1},
+				Radius:    0.5,
+			},
+			{
+				Type:      "directional",
+				Direction: []float64{1, -2, 1},
+				Intensity: []float64{0.3, 0.3, 0.3},
+			},
+			{
+				Type:      "spot",
+				Position:  []float64{0, 5, -5},
+				Direction: []float64{0, -1, 1},
+				Intensity: []float64{1, 1, 1},
+				Inner:     0.3,
+				Outer:     0.5,
+				Attenuation: cfg.Attenuation{
+					Type:      "polynomial",
+					Constant:  1,
+					Linear:    0.1,
+					Quadratic: 0.01,
+				},
+				CastsShadows: &no,
+			},
 		},
 		Objects: []cfg.Object{
 			{
@@ -51,14 +115,16 @@ func TestRead(t *testing.T) {
 					},
 				},
 				Material: cfg.Material{
-					Color:           []float64{0.8, 0.5, 0.3},
-					Ambient:         0.1,
-					Diffuse:         0.9,
-					Specular:        0.9,
-					Shininess:       200.0,
-					Reflective:      0.0,
-					Transparency:    0.0,
-					RefractiveIndex: 1.0,
+					Color:            []float64{0.8, 0.5, 0.3},
+					Ambient:          0.1,
+					Diffuse:          0.9,
+					Specular:         0.9,
+					Shininess:        200.0,
+					Reflective:       0.0,
+					Transparency:     0.0,
+					RefractiveIndex:  1.0,
+					Emission:         []float64{1, 0.5, 0},
+					EmissionStrength: 2,
 				},
 			},
 			{
@@ -82,6 +148,16 @@ func TestRead(t *testing.T) {
 								Values: []float64{0.4, 0.4, 0.4},
 							},
 						},
+						Perturb: cfg.Perturb{
+							Amount: 0.2,
+							Noise: cfg.Noise{
+								Type:       "simplex",
+								Octaves:    3,
+								Lacunarity: 2.5,
+								Gain:       0.4,
+								Seed:       42,
+							},
+						},
 					},
 					Ambient:         0.1,
 					Diffuse:         0.9,
@@ -90,6 +166,24 @@ func TestRead(t *testing.T) {
 					Reflective:      0.0,
 					Transparency:    0.0,
 					RefractiveIndex: 1.0,
+					BSDF: cfg.BSDF{
+						Type:      "ggx",
+						Roughness: 0.3,
+						Metallic:  1,
+					},
+					Bump: cfg.Bump{
+						Strength: 0.2,
+						Pattern: cfg.Pattern{
+							Type:   "clouds",
+							Colors: [][]float64{{0, 0, 0}, {1, 1, 1}},
+							Transform: []cfg.Transform{
+								{
+									Type:   "scale",
+									Values: []float64{0.1, 0.1, 0.1},
+								},
+							},
+						},
+					},
 				},
 			},
 			{
@@ -100,6 +194,34 @@ func TestRead(t *testing.T) {
 						Values: []float64{0.4, 0.4, 0.4},
 					},
 				},
+				Material: cfg.Material{
+					Pattern: cfg.Pattern{
+						Type:    "texture",
+						File:    "/examples/textures/crate.png",
+						Mapping: "cube",
+						Filter:  "bilinear",
+						Wrap:    "clamp",
+						SRGB:    true,
+					},
+					Bump: cfg.Bump{
+						Type: "normal",
+						Pattern: cfg.Pattern{
+							Type:    "texture",
+							File:    "/examples/textures/crate_normal.png",
+							Mapping: "cube",
+							Filter:  "bilinear",
+						},
+					},
+					Ambient:         0.1,
+					Diffuse:         0.9,
+					Specular:        0.9,
+					Shininess:       200.0,
+					Reflective:      0.0,
+					Transparency:    0.0,
+					RefractiveIndex: 1.0,
+				},
+				CastsShadow:    &no,
+				ReceivesShadow: &yes,
 			},
 			{
 				Type: "cylinder",
@@ -109,10 +231,212 @@ func TestRead(t *testing.T) {
 						Values: []float64{0.4, 0.4, 0.4},
 					},
 				},
+				Material: cfg.Material{
+					Pattern: cfg.Pattern{
+						Type: "uv_checker",
+						Colors: [][]float64{
+							{1, 1, 1},
+							{0, 0, 0},
+						},
+						Width:  16,
+						Height: 8,
+					},
+					Ambient:         0.1,
+					Diffuse:         0.9,
+					Specular:        0.9,
+					Shininess:       200.0,
+					Reflective:      0.0,
+					Transparency:    0.0,
+					RefractiveIndex: 1.0,
+				},
 				Minimum: 0,
 				Maximum: 1,
 				Closed:  true,
 			},
+			{
+				Type: "cone",
+				Transform: []cfg.Transform{
+					{
+						Type:   "translate",
+						Values: []float64{2, 0, 1},
+					},
+				},
+				Minimum: 0.5,
+				Maximum: 1,
+				Closed:  true,
+			},
+			{
+				Type:        "torus",
+				MajorRadius: 1.5,
+				MinorRadius: 0.2,
+			},
+			{
+				Type:        "disk",
+				InnerRadius: 0.5,
+				Transform: []cfg.Transform{
+					{
+						Type:   "translate",
+						Values: []float64{0, 3, 0},
+					},
+				},
+			},
+			{
+				Type: "quad",
+				Transform: []cfg.Transform{
+					{
+						Type:   "scale",
+						Values: []float64{2, 1, 0.5},
+					},
+				},
+			},
+			{
+				Type: "sdf",
+				SDF: &cfg.SDF{
+					Type:       "union",
+					Smoothness: 0.2,
+					Children: []cfg.SDF{
+						{
+							Type:   "rounded_box",
+							Size:   []float64{1, 0.5, 0.5},
+							Radius: 0.1,
+						},
+						{
+							Type:   "capsule",
+							From:   []float64{0, 0, 0},
+							To:     []float64{0, 1, 0},
+							Radius: 0.2,
+							Offset: []float64{0.5, 0, 0},
+						},
+						{
+							Type:        "torus",
+							MajorRadius: 0.4,
+							MinorRadius: 0.1,
+							Repeat:      []float64{2, 0, 0},
+						},
+					},
+				},
+			},
+			{
+				Type: "heightfield",
+				Noise: &cfg.Noise{
+					Type: "simplex",
+					Seed: 7,
+				},
+				Resolution: 64,
+				Frequency:  3,
+				Transform: []cfg.Transform{
+					{
+						Type:   "scale",
+						Values: []float64{10, 2, 10},
+					},
+				},
+			},
+			{
+				Type:         "model",
+				File:         "/examples/models/mug.obj",
+				LightSamples: 3,
+			},
+			{
+				Type: "sphere",
+				Material: cfg.Material{
+					Pattern: cfg.Pattern{
+						Type: "mask",
+						Patterns: []cfg.Pattern{
+							{
+								Type: "checker",
+								Patterns: []cfg.Pattern{
+									{
+										Type:   "stripe",
+										Colors: [][]float64{{1, 0, 0}, {1, 1, 1}},
+									},
+									{
+										Type:   "stripe",
+										Colors: [][]float64{{0, 1, 0}, {0, 0, 1}},
+										Transform: []cfg.Transform{
+											{
+												Type:   "rotate-y",
+												Values: []float64{1.5708},
+											},
+										},
+									},
+								},
+							},
+							{
+								Type:   "blend",
+								Weight: 0.3,
+								Patterns: []cfg.Pattern{
+									{
+										Type:   "marble",
+										Colors: [][]float64{{1, 1, 1}, {0, 0, 0}},
+									},
+									{
+										Type: "multiply",
+										Patterns: []cfg.Pattern{
+											{
+												Type:   "gradient",
+												Colors: [][]float64{{1, 1, 1}, {0, 0, 0}},
+											},
+											{
+												Type:   "ring",
+												Colors: [][]float64{{1, 0.5, 0}, {0, 0.5, 1}},
+											},
+										},
+									},
+								},
+							},
+						},
+						Mask: &cfg.Pattern{
+							Type:   "clouds",
+							Colors: [][]float64{{0, 0, 0}, {1, 1, 1}},
+						},
+					},
+					Ambient:         0.1,
+					Diffuse:         0.9,
+					Specular:        0.9,
+					Shininess:       200.0,
+					Reflective:      0.0,
+					Transparency:    0.0,
+					RefractiveIndex: 1.0,
+				},
+			},
+			{
+				Type:      "csg",
+				Operation: "difference",
+				Transform: []cfg.Transform{
+					{
+						Type:   "translate",
+						Values: []float64{0, 1, 0},
+					},
+				},
+				CastsShadow: &no,
+				Left: &cfg.Object{
+					Type: "cube",
+				},
+				Right: &cfg.Object{
+					Type:      "csg",
+					Operation: "union",
+					Left: &cfg.Object{
+						Type:    "cylinder",
+						Minimum: -2,
+						Maximum: 2,
+						Transform: []cfg.Transform{
+							{
+								Type:   "scale",
+								Values: []float64{0.5, 1, 0.5},
+							},
+						},
+					},
+					Right: &cfg.Object{
+						Type: "sphere",
+						Transform: []cfg.Transform{
+							{
+								Type:   "scale",
+								Values: []float64{1.3, 1.3, 1.3},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 
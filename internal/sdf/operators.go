@@ -0,0 +1,100 @@
+package sdf
+
+import (
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Union is the surface of both fields. With a smoothness above 0 the two surfaces melt into each other,
+// the smoothness is roughly the size of the blended region.
+func Union(a, b Field, smoothness float64) Field {
+	// the blend can grow the surface by a quarter of the smoothness.
+	pad := smoothness / 4
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return smoothMin(a.Distance(p), b.Distance(p), smoothness)
+		},
+		Min: tuple.NewPoint(math.Min(a.Min.X, b.Min.X)-pad, math.Min(a.Min.Y, b.Min.Y)-pad, math.Min(a.Min.Z, b.Min.Z)-pad),
+		Max: tuple.NewPoint(math.Max(a.Max.X, b.Max.X)+pad, math.Max(a.Max.Y, b.Max.Y)+pad, math.Max(a.Max.Z, b.Max.Z)+pad),
+	}
+}
+
+// Subtract carves the second field out of the first one, the smoothness rounds the edges of the cut.
+func Subtract(a, b Field, smoothness float64) Field {
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return smoothMax(a.Distance(p), -b.Distance(p), smoothness)
+		},
+		// the result never reaches outside of the first field.
+		Min: a.Min,
+		Max: a.Max,
+	}
+}
+
+// Intersection is the part where the fields overlap, the smoothness rounds the edges.
+func Intersection(a, b Field, smoothness float64) Field {
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return smoothMax(a.Distance(p), b.Distance(p), smoothness)
+		},
+		Min: tuple.NewPoint(math.Max(a.Min.X, b.Min.X), math.Max(a.Min.Y, b.Min.Y), math.Max(a.Min.Z, b.Min.Z)),
+		Max: tuple.NewPoint(math.Min(a.Max.X, b.Max.X), math.Min(a.Max.Y, b.Max.Y), math.Min(a.Max.Z, b.Max.Z)),
+	}
+}
+
+// Translate moves the field by the offset.
+func Translate(f Field, offset tuple.Tuple) Field {
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return f.Distance(tuple.Subtract(p, offset))
+		},
+		Min: tuple.Add(f.Min, offset),
+		Max: tuple.Add(f.Max, offset),
+	}
+}
+
+// Repeat copies the field endlessly, every period units along each axis. A period of 0 leaves the axis alone.
+// The copies are centered around the multiples of the period. The field should fit into a single period,
+// otherwise the neighbouring copies are cut off.
+func Repeat(f Field, period tuple.Tuple) Field {
+	min, max := f.Min, f.Max
+	if period.X > 0 {
+		min.X, max.X = math.Inf(-1), math.Inf(1)
+	}
+	if period.Y > 0 {
+		min.Y, max.Y = math.Inf(-1), math.Inf(1)
+	}
+	if period.Z > 0 {
+		min.Z, max.Z = math.Inf(-1), math.Inf(1)
+	}
+
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return f.Distance(tuple.NewPoint(repeat(p.X, period.X), repeat(p.Y, period.Y), repeat(p.Z, period.Z)))
+		},
+		Min: min,
+		Max: max,
+	}
+}
+
+// the position inside of the period, centered around 0.
+func repeat(x, period float64) float64 {
+	if period <= 0 {
+		return x
+	}
+	return x - period*math.Round(x/period)
+}
+
+// polynomial smooth minimum, the hard minimum when k is 0.
+func smoothMin(a, b, k float64) float64 {
+	if k <= 0 {
+		return math.Min(a, b)
+	}
+	h := math.Min(math.Max(0.5+0.5*(b-a)/k, 0), 1)
+	return b + (a-b)*h - k*h*(1-h)
+}
+
+func smoothMax(a, b, k float64) float64 {
+	return -smoothMin(-a, -b, k)
+}
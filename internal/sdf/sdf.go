@@ -0,0 +1,80 @@
+// Signed distance functions describe a surface by the distance of any point from it,
+// negative inside and positive outside. They are combined and repeated by wrapping one function in another.
+// The primitives follow Inigo Quilez's formulas.
+package sdf
+
+import (
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Field is a signed distance function with the bounds of its surface.
+// The distance may be an underestimate, that only slows down the marching, but it must never overestimate.
+// Min and Max are infinite on the axes where the surface is unbounded, e.g. when it's repeated.
+type Field struct {
+	Distance func(p tuple.Tuple) float64
+	Min, Max tuple.Tuple
+}
+
+// Sphere is a sphere around the origin.
+func Sphere(radius float64) Field {
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return length(p.X, p.Y, p.Z) - radius
+		},
+		Min: tuple.NewPoint(-radius, -radius, -radius),
+		Max: tuple.NewPoint(radius, radius, radius),
+	}
+}
+
+// RoundedBox is a box around the origin, size is half of its width, height and depth.
+// The edges are rounded with the radius, the box keeps its size.
+func RoundedBox(size tuple.Tuple, radius float64) Field {
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			qx := math.Abs(p.X) - size.X + radius
+			qy := math.Abs(p.Y) - size.Y + radius
+			qz := math.Abs(p.Z) - size.Z + radius
+			outside := length(math.Max(qx, 0), math.Max(qy, 0), math.Max(qz, 0))
+			inside := math.Min(math.Max(qx, math.Max(qy, qz)), 0)
+			return outside + inside - radius
+		},
+		Min: tuple.NewPoint(-size.X, -size.Y, -size.Z),
+		Max: tuple.NewPoint(size.X, size.Y, size.Z),
+	}
+}
+
+// Capsule is a line segment between two points, thickened by the radius.
+func Capsule(from, to tuple.Tuple, radius float64) Field {
+	segment := tuple.Subtract(to, from)
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			fromP := tuple.Subtract(p, from)
+			// the closest point of the segment, 0 at from and 1 at to.
+			h := 0.0
+			if lengthSq := tuple.Dot(segment, segment); lengthSq > 0 {
+				h = math.Min(math.Max(tuple.Dot(fromP, segment)/lengthSq, 0), 1)
+			}
+			return tuple.Subtract(fromP, segment.Scalar(h)).Magnitude() - radius
+		},
+		Min: tuple.NewPoint(math.Min(from.X, to.X)-radius, math.Min(from.Y, to.Y)-radius, math.Min(from.Z, to.Z)-radius),
+		Max: tuple.NewPoint(math.Max(from.X, to.X)+radius, math.Max(from.Y, to.Y)+radius, math.Max(from.Z, to.Z)+radius),
+	}
+}
+
+// Torus is a ring around the y axis, with the tube of the minor radius at the major radius from the axis.
+func Torus(majorRadius, minorRadius float64) Field {
+	outer := majorRadius + minorRadius
+	return Field{
+		Distance: func(p tuple.Tuple) float64 {
+			return length(length(p.X, 0, p.Z)-majorRadius, p.Y, 0) - minorRadius
+		},
+		Min: tuple.NewPoint(-outer, -minorRadius, -outer),
+		Max: tuple.NewPoint(outer, minorRadius, outer),
+	}
+}
+
+func length(x, y, z float64) float64 {
+	return math.Sqrt(x*x + y*y + z*z)
+}
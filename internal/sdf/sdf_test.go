@@ -0,0 +1,197 @@
+package sdf
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestPrimitives(t *testing.T) {
+	var tests = []struct {
+		name     string
+		field    Field
+		point    tuple.Tuple
+		expected float64
+	}{
+		{
+			name:     "sphere outside",
+			field:    Sphere(1),
+			point:    tuple.NewPoint(0, 3, 0),
+			expected: 2,
+		},
+		{
+			name:     "sphere inside",
+			field:    Sphere(2),
+			point:    tuple.NewPoint(0.5, 0, 0),
+			expected: -1.5,
+		},
+		{
+			name:     "rounded box face",
+			field:    RoundedBox(tuple.NewVector(1, 2, 3), 0.5),
+			point:    tuple.NewPoint(0, 0, 4),
+			expected: 1,
+		},
+		{
+			name:     "rounded box center",
+			field:    RoundedBox(tuple.NewVector(1, 2, 3), 0.5),
+			point:    tuple.NewPoint(0, 0, 0),
+			expected: -1,
+		},
+		// the corner is rounded, its distance is from the rounding sphere
+		{
+			name:     "rounded box corner",
+			field:    RoundedBox(tuple.NewVector(1, 1, 1), 0.5),
+			point:    tuple.NewPoint(1.5, 1.5, 0),
+			expected: math.Sqrt2 - 0.5,
+		},
+		{
+			name:     "capsule side",
+			field:    Capsule(tuple.NewPoint(0, 0, 0), tuple.NewPoint(0, 2, 0), 0.5),
+			point:    tuple.NewPoint(1, 1, 0),
+			expected: 0.5,
+		},
+		{
+			name:     "capsule end",
+			field:    Capsule(tuple.NewPoint(0, 0, 0), tuple.NewPoint(0, 2, 0), 0.5),
+			point:    tuple.NewPoint(0, 4, 0),
+			expected: 1.5,
+		},
+		{
+			name:     "torus tube",
+			field:    Torus(1, 0.25),
+			point:    tuple.NewPoint(0, 0, -1),
+			expected: -0.25,
+		},
+		{
+			name:     "torus hole",
+			field:    Torus(1, 0.25),
+			point:    tuple.NewPoint(0, 0, 0),
+			expected: 0.75,
+		},
+	}
+
+	for _, test := range tests {
+		if result := test.field.Distance(test.point); !utils.FloatEquals(result, test.expected) {
+			t.Errorf("%s:\nresult: %f\nexpected: %f", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestOperators(t *testing.T) {
+	a := Sphere(1)
+	b := Translate(Sphere(1), tuple.NewVector(1.5, 0, 0))
+
+	var tests = []struct {
+		name     string
+		field    Field
+		point    tuple.Tuple
+		expected float64
+	}{
+		{
+			name:     "union",
+			field:    Union(a, b, 0),
+			point:    tuple.NewPoint(3, 0, 0),
+			expected: 0.5,
+		},
+		{
+			name:     "subtract",
+			field:    Subtract(a, b, 0),
+			point:    tuple.NewPoint(0, 0, 0),
+			expected: -0.5,
+		},
+		{
+			name:     "subtract cut out",
+			field:    Subtract(a, b, 0),
+			point:    tuple.NewPoint(0.75, 0, 0),
+			expected: 0.25,
+		},
+		{
+			name:     "intersection",
+			field:    Intersection(a, b, 0),
+			point:    tuple.NewPoint(0.75, 0, 0),
+			expected: -0.25,
+		},
+		// in the middle both distances are the same, the blend pulls the surface out by a quarter of the smoothness.
+		{
+			name:     "smooth union",
+			field:    Union(a, Translate(Sphere(1), tuple.NewVector(3, 0, 0)), 1),
+			point:    tuple.NewPoint(1.5, 0, 0),
+			expected: 0.25,
+		},
+		{
+			name:     "smooth subtract",
+			field:    Subtract(a, Translate(Sphere(1), tuple.NewVector(2, 0, 0)), 1),
+			point:    tuple.NewPoint(1.5, 0, 0),
+			expected: 0.75,
+		},
+		{
+			name:     "repeat",
+			field:    Repeat(a, tuple.NewVector(4, 0, 0)),
+			point:    tuple.NewPoint(-8.5, 0, 0),
+			expected: -0.5,
+		},
+		{
+			name:     "repeat leaves the other axes",
+			field:    Repeat(a, tuple.NewVector(4, 0, 0)),
+			point:    tuple.NewPoint(8, 5, 0),
+			expected: 4,
+		},
+	}
+
+	for _, test := range tests {
+		if result := test.field.Distance(test.point); !utils.FloatEquals(result, test.expected) {
+			t.Errorf("%s:\nresult: %f\nexpected: %f", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	a := Sphere(1)
+	b := Translate(Sphere(1), tuple.NewVector(1.5, 0, 0))
+
+	var tests = []struct {
+		name     string
+		field    Field
+		min, max tuple.Tuple
+	}{
+		{
+			name:  "capsule",
+			field: Capsule(tuple.NewPoint(0, 0, 0), tuple.NewPoint(1, 2, 0), 0.5),
+			min:   tuple.NewPoint(-0.5, -0.5, -0.5),
+			max:   tuple.NewPoint(1.5, 2.5, 0.5),
+		},
+		{
+			name:  "smooth union",
+			field: Union(a, b, 0.4),
+			min:   tuple.NewPoint(-1.1, -1.1, -1.1),
+			max:   tuple.NewPoint(2.6, 1.1, 1.1),
+		},
+		{
+			name:  "subtract",
+			field: Subtract(a, b, 0.4),
+			min:   tuple.NewPoint(-1, -1, -1),
+			max:   tuple.NewPoint(1, 1, 1),
+		},
+		{
+			name:  "intersection",
+			field: Intersection(a, b, 0),
+			min:   tuple.NewPoint(0.5, -1, -1),
+			max:   tuple.NewPoint(1, 1, 1),
+		},
+		{
+			name:  "repeat",
+			field: Repeat(a, tuple.NewVector(0, 0, 3)),
+			min:   tuple.NewPoint(-1, -1, math.Inf(-1)),
+			max:   tuple.NewPoint(1, 1, math.Inf(1)),
+		},
+	}
+
+	for _, test := range tests {
+		// Equal would compare the infinite sides as Inf - Inf = NaN
+		if test.field.Min != test.min || test.field.Max != test.max {
+			t.Errorf("%s:\nresult: %s %s\nexpected: %s %s", test.name, test.field.Min, test.field.Max, test.min, test.max)
+		}
+	}
+}
@@ -109,3 +109,50 @@ func (b *BoundingBox) Split() (left, right *BoundingBox) {
 	right = NewBoundingBox(midMin, b.Max)
 	return left, right
 }
+
+// a box is bounded when none of its sides are at infinity. Empty boxes are not bounded either.
+func (b *BoundingBox) bounded() bool {
+	for _, v := range []float64{b.Min.X, b.Min.Y, b.Min.Z, b.Max.X, b.Max.Y, b.Max.Z} {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BoundingBox) centroid() tuple.Tuple {
+	return tuple.NewPoint((b.Min.X+b.Max.X)/2, (b.Min.Y+b.Max.Y)/2, (b.Min.Z+b.Max.Z)/2)
+}
+
+func (b *BoundingBox) surfaceArea() float64 {
+	dx := b.Max.X - b.Min.X
+	dy := b.Max.Y - b.Min.Y
+	dz := b.Max.Z - b.Min.Z
+	if dx < 0 || dy < 0 || dz < 0 {
+		return 0
+	}
+	return 2 * (dx*dy + dy*dz + dz*dx)
+}
+
+// Slab test of the ray against the box, inverse is the reciprocal of the ray's direction.
+// Returns the distances where the ray enters and leaves the box.
+func (b *BoundingBox) slabs(origin, inverse tuple.Tuple) (tmin, tmax float64, ok bool) {
+	tmin, tmax = math.Inf(-1), math.Inf(1)
+	for axis := 0; axis < 3; axis++ {
+		o, inv := axisOf(origin, axis), axisOf(inverse, axis)
+		low, high := axisOf(b.Min, axis), axisOf(b.Max, axis)
+		if math.IsInf(inv, 0) {
+			// the ray is parallel to the slab, it either always or never overlaps it.
+			if o < low || o > high {
+				return 0, 0, false
+			}
+			continue
+		}
+		t1, t2 := (low-o)*inv, (high-o)*inv
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tmin, tmax = math.Max(tmin, t1), math.Min(tmax, t2)
+	}
+	return tmin, tmax, tmin <= tmax
+}
@@ -0,0 +1,92 @@
+package shapes
+
+import (
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// the step of the finite differences, small enough to stay within a texel of a large texture.
+const bumpDelta = 1e-3
+
+// Tilts the normal at a point in object space according to the bump or normal map.
+func bumpNormal(bump *materials.Bump, point, normal tuple.Tuple, shape Shape) tuple.Tuple {
+	normal = normal.Normalize()
+	if bump.NormalMap {
+		return normalMapNormal(bump, point, normal, shape)
+	}
+
+	// the gradient of the height, the normal leans away from where the surface rises.
+	height := bumpHeight(bump, point, shape)
+	gradient := tuple.NewVector(
+		(bumpHeight(bump, tuple.Add(point, tuple.NewVector(bumpDelta, 0, 0)), shape)-height)/bumpDelta,
+		(bumpHeight(bump, tuple.Add(point, tuple.NewVector(0, bumpDelta, 0)), shape)-height)/bumpDelta,
+		(bumpHeight(bump, tuple.Add(point, tuple.NewVector(0, 0, bumpDelta)), shape)-height)/bumpDelta,
+	)
+	// only the part along the surface tilts the normal.
+	gradient = tuple.Subtract(gradient, normal.Scalar(tuple.Dot(gradient, normal)))
+
+	return tuple.Subtract(normal, gradient.Scalar(bump.Strength)).Normalize()
+}
+
+// The brightness of the pattern.
+func bumpHeight(bump *materials.Bump, point tuple.Tuple, shape Shape) float64 {
+	c := patternColorAt(bump.Pattern, point, shape)
+	return (c.R + c.G + c.B) / 3
+}
+
+// The colors of a normal map are normals in tangent space: red points along u, green along v and blue away from the surface.
+func normalMapNormal(bump *materials.Bump, point, normal tuple.Tuple, shape Shape) tuple.Tuple {
+	tangent, bitangent := tangents(bump.Pattern, point, normal, shape)
+	c := patternColorAt(bump.Pattern, point, shape)
+	// the channels are stored from 0 to 1, the components of the normal are from -1 to 1.
+	x, y, z := (2*c.R-1)*bump.Strength, (2*c.G-1)*bump.Strength, 2*c.B-1
+
+	return tuple.Add(
+		tuple.Add(tangent.Scalar(x), bitangent.Scalar(y)),
+		normal.Scalar(z),
+	).Normalize()
+}
+
+// Returns the directions in which the texture coordinates grow along the surface.
+// They follow any mapping, including the texture coordinates of the vertices of smooth triangles.
+func tangents(pattern *materials.Pattern, point, normal tuple.Tuple, shape Shape) (tuple.Tuple, tuple.Tuple) {
+	uv := func(p tuple.Tuple) (float64, float64) {
+		return uvAt(p, tuple.Multiply(pattern.Transform().Inverse(), p), shape, pattern.Mapping())
+	}
+	u, v := uv(point)
+	var du, dv [3]float64
+	for axis, step := range []tuple.Tuple{
+		tuple.NewVector(bumpDelta, 0, 0),
+		tuple.NewVector(0, bumpDelta, 0),
+		tuple.NewVector(0, 0, bumpDelta),
+	} {
+		su, sv := uv(tuple.Add(point, step))
+		du[axis], dv[axis] = seamless(su-u)/bumpDelta, seamless(sv-v)/bumpDelta
+	}
+
+	// Gram-Schmidt, the tangent is kept along the surface.
+	tangent := tuple.NewVector(du[0], du[1], du[2])
+	tangent = tuple.Subtract(tangent, normal.Scalar(tuple.Dot(tangent, normal)))
+	if tangent.Magnitude() == 0 {
+		tangent, _ = tuple.Basis(normal)
+	}
+	tangent = tangent.Normalize()
+
+	bitangent := tuple.Cross(normal, tangent)
+	// the handedness depends on the mapping, the bitangent points to where v grows.
+	if tuple.Dot(bitangent, tuple.NewVector(dv[0], dv[1], dv[2])) < 0 {
+		bitangent = bitangent.Negate()
+	}
+	return tangent, bitangent
+}
+
+// Texture coordinates wrap around, a step across the seam is a small step the other way.
+func seamless(d float64) float64 {
+	if d > 0.5 {
+		return d - 1
+	}
+	if d < -0.5 {
+		return d + 1
+	}
+	return d
+}
@@ -0,0 +1,124 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// A flat texture of a single color.
+func flatTexture(c color.Color, mapping materials.Mapping) *materials.Pattern {
+	image := canvas.New(1, 1)
+	image[0][0] = c
+	return materials.NewTexturePattern(materials.NewTexture(image, materials.NearestFilter, materials.RepeatWrap), mapping)
+}
+
+func TestBumpMap(t *testing.T) {
+	// The height grows along x, the normal leans towards -x
+	plane := NewPlane()
+	plane.Material().Bump = &materials.Bump{
+		Pattern:  materials.NewPattern(materials.Gradient, color.Black(), color.White()),
+		Strength: 0.5,
+	}
+	point := tuple.NewPoint(0.5, 0, 0.5)
+	expected := tuple.NewVector(-0.5, 1, 0).Normalize()
+
+	if result := NormalAt(point, plane, Intersection{}); !result.Equal(expected) {
+		t.Errorf("bump map: expected %s, got %s", expected, result)
+	}
+
+	// A constant height keeps the normal
+	plane.Material().Bump.Pattern = materials.NewPattern(materials.Base, color.White())
+	if result := NormalAt(point, plane, Intersection{}); !result.Equal(tuple.NewVector(0, 1, 0)) {
+		t.Errorf("flat bump map: expected the normal to be unchanged, got %s", result)
+	}
+}
+
+func TestNormalMap(t *testing.T) {
+	var tests = []struct {
+		name     string
+		shape    Shape
+		mapping  materials.Mapping
+		color    color.Color
+		point    tuple.Tuple
+		expected tuple.Tuple
+	}{
+		{
+			name:     "pointing away from the surface",
+			shape:    NewPlane(),
+			color:    color.New(0.5, 0.5, 1),
+			point:    tuple.NewPoint(0.3, 0, 0.3),
+			expected: tuple.NewVector(0, 1, 0),
+		},
+		{
+			name:     "red points along u",
+			shape:    NewPlane(),
+			color:    color.New(1, 0.5, 0.5),
+			point:    tuple.NewPoint(0.3, 0, 0.3),
+			expected: tuple.NewVector(1, 0, 0),
+		},
+		{
+			name:     "green points along v",
+			shape:    NewPlane(),
+			color:    color.New(0.5, 1, 0.5),
+			point:    tuple.NewPoint(0.3, 0, 0.3),
+			expected: tuple.NewVector(0, 0, 1),
+		},
+		{
+			// u grows towards x in front of the sphere, v grows upwards
+			name:     "sphere",
+			shape:    NewSphere(),
+			color:    color.New(1, 0.5, 0.5),
+			point:    tuple.NewPoint(0, 0, -1),
+			expected: tuple.NewVector(1, 0, 0),
+		},
+		{
+			name:     "sphere across the seam",
+			shape:    NewSphere(),
+			color:    color.New(0.5, 1, 0.5),
+			point:    tuple.NewPoint(0, 0, 1),
+			expected: tuple.NewVector(0, 1, 0),
+		},
+	}
+
+	for _, test := range tests {
+		test.shape.Material().Bump = &materials.Bump{
+			Pattern:   flatTexture(test.color, test.mapping),
+			NormalMap: true,
+			Strength:  1,
+		}
+		if result := NormalAt(test.point, test.shape, Intersection{}); !result.Equal(test.expected) {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, result)
+		}
+	}
+}
+
+func TestNormalMapOnSmoothTriangle(t *testing.T) {
+	// The tangents follow the texture coordinates of the vertices, here u grows along -x
+	model := NewModel(`v 0 1 0
+v -1 0 0
+v 1 0 0
+vt 0.5 1
+vt 1 0
+vt 0 0
+vn 0 0 -1
+f 1/1/1 2/2/1 3/3/1
+`)
+	model.SetMaterial(materials.DefaultMaterial())
+	model.Material().Bump = &materials.Bump{
+		Pattern:   flatTexture(color.New(0.5+math.Sqrt(2)/4, 0.5, 0.5+math.Sqrt(2)/4), materials.AutoMapping),
+		NormalMap: true,
+		Strength:  1,
+	}
+	triangle := model.Triangles()[0]
+	hit := NewIntersectionWithUV(1, 0.25, 0.25, triangle)
+	expected := tuple.NewVector(-1, 0, -1).Normalize()
+
+	if result := NormalAt(tuple.NewPoint(0, 0.5, 0), triangle, hit); !result.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, result)
+	}
+}
@@ -0,0 +1,358 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// DefaultLeafSize is the number of shapes below which a node may become a leaf of the hierarchy.
+const DefaultLeafSize = 4
+
+const (
+	// number of buckets the centroids are sorted into when looking for the best split.
+	sahBuckets = 12
+	// cost of visiting a node relative to intersecting a primitive shape.
+	traversalCost = 0.125
+)
+
+// BVH is a bounding volume hierarchy built with the surface area heuristic.
+// The tree is flattened into an array in depth first order, the first child of
+// an interior node is the next node in the array, the second child is at offset.
+type BVH struct {
+	nodes     []bvhNode
+	shapes    []Shape // the bounded shapes, ordered so the shapes of a leaf are next to each other.
+	unbounded []Shape // shapes with infinite bounds, like planes. These are always intersected.
+}
+
+type bvhNode struct {
+	box BoundingBox
+	// for interior nodes the index of the second child,
+	// for leaves the index of the first shape.
+	offset int
+	count  int // the number of shapes in a leaf, 0 for interior nodes.
+}
+
+func (n *bvhNode) leaf() bool {
+	return n.count > 0
+}
+
+// BVHStats describes the shape of a built hierarchy.
+type BVHStats struct {
+	Nodes, Leaves, Depth     int
+	MinLeafSize, MaxLeafSize int
+	AverageLeafSize          float64
+	Unbounded                int // shapes outside of the hierarchy because they are infinite.
+}
+
+func (s BVHStats) String() string {
+	return fmt.Sprintf("BVH(nodes: %d, leaves: %d, depth: %d, leaf size: %d-%d avg %.2f, unbounded: %d)",
+		s.Nodes, s.Leaves, s.Depth, s.MinLeafSize, s.MaxLeafSize, s.AverageLeafSize, s.Unbounded)
+}
+
+// information about a shape needed during the build.
+type bvhPrimitive struct {
+	shape    Shape
+	box      BoundingBox
+	centroid tuple.Tuple
+	cost     float64
+}
+
+// NewBVH builds a hierarchy over the shapes. The bounding boxes of the shapes have to be calculated beforehand.
+// Nodes with at most leafSize shapes become leaves when splitting them further would not be cheaper.
+func NewBVH(children []Shape, leafSize int) *BVH {
+	if leafSize < 1 {
+		leafSize = DefaultLeafSize
+	}
+
+	b := &BVH{}
+	prims := []bvhPrimitive{}
+	for i := 0; i < len(children); i++ {
+		box := parentSpaceBox(children[i])
+		if !box.bounded() {
+			b.unbounded = append(b.unbounded, children[i])
+			continue
+		}
+		prims = append(prims, bvhPrimitive{
+			shape:    children[i],
+			box:      *box,
+			centroid: box.centroid(),
+			cost:     float64(primitiveCount(children[i])),
+		})
+	}
+
+	if len(prims) > 0 {
+		b.build(prims, leafSize)
+	}
+	return b
+}
+
+// recursively splits the primitives and appends the nodes in depth first order.
+func (b *BVH) build(prims []bvhPrimitive, leafSize int) int {
+	index := len(b.nodes)
+	b.nodes = append(b.nodes, bvhNode{box: *DefaultBoundingBox()})
+
+	box := DefaultBoundingBox()
+	centroids := DefaultBoundingBox()
+	leafCost := 0.0
+	for i := 0; i < len(prims); i++ {
+		box.AddBox(&prims[i].box)
+		centroids.AddPoint(prims[i].centroid)
+		leafCost += prims[i].cost
+	}
+	b.nodes[index].box = *box
+
+	mid, splitCost := sahSplit(prims, box, centroids)
+	if mid < 0 || (len(prims) <= leafSize && leafCost <= splitCost) {
+		b.nodes[index].offset = len(b.shapes)
+		b.nodes[index].count = len(prims)
+		for i := 0; i < len(prims); i++ {
+			b.shapes = append(b.shapes, prims[i].shape)
+		}
+		return index
+	}
+
+	b.build(prims[:mid], leafSize)
+	b.nodes[index].offset = b.build(prims[mid:], leafSize)
+	return index
+}
+
+// finds the cheapest split along any axis using the surface area heuristic.
+// The primitives are partitioned in place, the index of the first primitive of the right side is returned.
+// Returns -1 when the primitives can not be split, e.g. all of their centroids are at the same point.
+func sahSplit(prims []bvhPrimitive, box, centroids *BoundingBox) (int, float64) {
+	if len(prims) < 2 {
+		return -1, math.Inf(1)
+	}
+
+	bestAxis, bestBucket, bestCost := -1, 0, math.Inf(1)
+	parentArea := box.surfaceArea()
+
+	for axis := 0; axis < 3; axis++ {
+		low, high := axisOf(centroids.Min, axis), axisOf(centroids.Max, axis)
+		if high-low < utils.EPSILON {
+			continue
+		}
+
+		var boxes [sahBuckets]BoundingBox
+		var costs [sahBuckets]float64
+		for i := range boxes {
+			boxes[i] = *DefaultBoundingBox()
+		}
+		for i := 0; i < len(prims); i++ {
+			bucket := bucketOf(prims[i].centroid, axis, low, high)
+			boxes[bucket].AddBox(&prims[i].box)
+			costs[bucket] += prims[i].cost
+		}
+
+		// the cost of splitting after every bucket, sweeping from both sides.
+		var leftArea, leftCost [sahBuckets - 1]float64
+		left := DefaultBoundingBox()
+		sum := 0.0
+		for i := 0; i < sahBuckets-1; i++ {
+			if costs[i] > 0 {
+				// adding an empty box would stretch the sum to infinity.
+				left.AddBox(&boxes[i])
+			}
+			sum += costs[i]
+			leftArea[i], leftCost[i] = left.surfaceArea(), sum
+		}
+		right := DefaultBoundingBox()
+		sum = 0.0
+		for i := sahBuckets - 1; i > 0; i-- {
+			if costs[i] > 0 {
+				right.AddBox(&boxes[i])
+			}
+			sum += costs[i]
+			if leftCost[i-1] == 0 || sum == 0 {
+				continue
+			}
+			cost := traversalCost + (leftArea[i-1]*leftCost[i-1]+right.surfaceArea()*sum)/parentArea
+			if cost < bestCost {
+				bestAxis, bestBucket, bestCost = axis, i-1, cost
+			}
+		}
+	}
+
+	if bestAxis < 0 {
+		return -1, math.Inf(1)
+	}
+
+	// partition the primitives around the chosen bucket.
+	low, high := axisOf(centroids.Min, bestAxis), axisOf(centroids.Max, bestAxis)
+	mid := 0
+	for i := 0; i < len(prims); i++ {
+		if bucketOf(prims[i].centroid, bestAxis, low, high) <= bestBucket {
+			prims[i], prims[mid] = prims[mid], prims[i]
+			mid++
+		}
+	}
+	return mid, bestCost
+}
+
+func bucketOf(centroid tuple.Tuple, axis int, low, high float64) int {
+	bucket := int(sahBuckets * (axisOf(centroid, axis) - low) / (high - low))
+	return min(max(bucket, 0), sahBuckets-1)
+}
+
+func axisOf(t tuple.Tuple, axis int) float64 {
+	switch axis {
+	case 0:
+		return t.X
+	case 1:
+		return t.Y
+	default:
+		return t.Z
+	}
+}
+
+// Intersects the ray with the shapes in the hierarchy. Children are visited front to back and
+// nodes that start beyond the closest hit found so far are skipped, so every intersection up to the
+// closest hit is found, including the ones behind the ray's origin, but some beyond it may be left out.
+func (b *BVH) intersect(r *ray.Ray) Intersections {
+	return b.traverse(r, false)
+}
+
+// Intersects the ray with the shapes in the hierarchy without skipping any node, every intersection
+// is found. CSG shapes need them to know where the ray is inside of their children.
+func (b *BVH) intersectAll(r *ray.Ray) Intersections {
+	return b.traverse(r, true)
+}
+
+func (b *BVH) traverse(r *ray.Ray, all bool) Intersections {
+	xs := Intersections{}
+	closest := math.Inf(1)
+
+	for i := 0; i < len(b.unbounded); i++ {
+		xs = append(xs, intersect(b.unbounded[i], r, all)...)
+	}
+	closest = closestHit(xs, closest)
+
+	if len(b.nodes) == 0 {
+		return xs
+	}
+
+	inverse := tuple.NewVector(1/r.Direction.X, 1/r.Direction.Y, 1/r.Direction.Z)
+	if _, _, ok := b.nodes[0].box.slabs(r.Origin, inverse); !ok {
+		return xs
+	}
+
+	// nodes are pushed with their entry distance, so they can be skipped once a closer hit is known.
+	type entry struct {
+		node int
+		near float64
+	}
+	stack := []entry{{node: 0, near: math.Inf(-1)}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !all && current.near > closest {
+			continue
+		}
+
+		node := &b.nodes[current.node]
+		if node.leaf() {
+			for i := node.offset; i < node.offset+node.count; i++ {
+				hits := intersect(b.shapes[i], r, all)
+				xs = append(xs, hits...)
+				closest = closestHit(hits, closest)
+			}
+			continue
+		}
+
+		first, second := current.node+1, node.offset
+		firstNear, _, firstOk := b.nodes[first].box.slabs(r.Origin, inverse)
+		secondNear, _, secondOk := b.nodes[second].box.slabs(r.Origin, inverse)
+		// the nearer child goes on the top of the stack, so it is visited first.
+		if firstOk && secondOk && secondNear < firstNear {
+			first, second = second, first
+			firstNear, secondNear = secondNear, firstNear
+		} else if !firstOk {
+			first, firstNear, firstOk = second, secondNear, secondOk
+			secondOk = false
+		}
+		if secondOk {
+			stack = append(stack, entry{node: second, near: secondNear})
+		}
+		if firstOk {
+			stack = append(stack, entry{node: first, near: firstNear})
+		}
+	}
+
+	return xs
+}
+
+// returns the smallest non-negative t among the intersections, or current if it is smaller.
+func closestHit(xs Intersections, current float64) float64 {
+	for i := 0; i < len(xs); i++ {
+		if xs[i].t >= 0 && xs[i].t < current {
+			current = xs[i].t
+		}
+	}
+	return current
+}
+
+// Stats describes the built hierarchy.
+func (b *BVH) Stats() BVHStats {
+	stats := BVHStats{Nodes: len(b.nodes), Unbounded: len(b.unbounded), MinLeafSize: math.MaxInt}
+	if len(b.nodes) == 0 {
+		stats.MinLeafSize = 0
+		return stats
+	}
+
+	total := 0
+	var walk func(index, depth int)
+	walk = func(index, depth int) {
+		node := &b.nodes[index]
+		stats.Depth = max(stats.Depth, depth)
+		if node.leaf() {
+			stats.Leaves++
+			total += node.count
+			stats.MinLeafSize = min(stats.MinLeafSize, node.count)
+			stats.MaxLeafSize = max(stats.MaxLeafSize, node.count)
+			return
+		}
+		walk(index+1, depth+1)
+		walk(node.offset, depth+1)
+	}
+	walk(0, 1)
+
+	stats.AverageLeafSize = float64(total) / float64(stats.Leaves)
+	return stats
+}
+
+// The bounding box of the shape in the space of its parent. Groups and models keep their boxes in their own
+// space, because that's where the rays are tested against them, so their transform is applied here.
+func parentSpaceBox(s Shape) *BoundingBox {
+	box := *s.BoundingBox()
+	switch s.(type) {
+	case *Group, *Model, *CSG:
+		if box.bounded() {
+			TransformBoundingBox(&box, s.Transform())
+		}
+	}
+	return &box
+}
+
+// The number of primitive shapes inside the shape, used as the cost of intersecting it.
+// Groups and models count all of their descendants, a model with thousands of triangles is
+// much more expensive than a single sphere even when their bounding boxes are the same.
+func primitiveCount(s Shape) int {
+	switch shape := s.(type) {
+	case *Group:
+		count := 0
+		for i := 0; i < len(shape.children); i++ {
+			count += primitiveCount(shape.children[i])
+		}
+		return max(count, 1)
+	case *Model:
+		return primitiveCount(&shape.group)
+	case *CSG:
+		return primitiveCount(shape.left) + primitiveCount(shape.right)
+	default:
+		return 1
+	}
+}
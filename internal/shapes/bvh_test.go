@@ -0,0 +1,140 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func sphereGrid(n int) *Group {
+	g := NewGroup()
+	for x := 0; x < n; x++ {
+		for y := 0; y < n; y++ {
+			s := NewSphere()
+			s.SetTransform(matrix.Multiply(
+				matrix.Translation(float64(x)*3-float64(n), float64(y)*3-float64(n), float64((x*7+y*3)%5)),
+				matrix.Scaling(0.5+float64((x+y)%3)*0.4, 1, 1),
+			))
+			g.AddChild(s)
+		}
+	}
+	g.CalculateBoundingBoxCascade()
+	return g
+}
+
+func TestBVHIntersect(t *testing.T) {
+	// The hierarchy finds the same hits as testing every child.
+	g := sphereGrid(8)
+	expected := NewGroup()
+	expected.AddChild(g.Children()...)
+	expected.CalculateBoundingBoxCascade()
+	g.BuildBVH(2)
+
+	for i := 0; i < 200; i++ {
+		angle := float64(i) * 0.1
+		r := ray.New(
+			tuple.NewPoint(math.Cos(angle)*30, math.Sin(angle*1.3)*20, -20),
+			tuple.NewVector(-math.Cos(angle)+math.Sin(angle*7)*0.3, -math.Sin(angle*1.3)*0.6, 1).Normalize(),
+		)
+		result, all := Intersect(g, r), Intersect(expected, r)
+
+		resultHit, expectedHit := result.Hit(), all.Hit()
+		if resultHit.Empty() != expectedHit.Empty() ||
+			!expectedHit.Empty() && (resultHit.shape != expectedHit.shape || !utils.FloatEquals(resultHit.t, expectedHit.t)) {
+			t.Errorf("ray %s hit expected %v, got %v", r, expectedHit, resultHit)
+		}
+
+		// every intersection up to the hit has to be found.
+		for _, x := range all {
+			if !expectedHit.Empty() && x.t > expectedHit.t {
+				break
+			}
+			found := false
+			for _, y := range result {
+				if x.shape == y.shape && utils.FloatEquals(x.t, y.t) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("ray %s missing intersection %v", r, x)
+			}
+		}
+	}
+}
+
+func TestBVHUnbounded(t *testing.T) {
+	// Infinite shapes are kept outside of the tree and always tested.
+	g := NewGroup()
+	p := NewPlane()
+	s := NewSphere()
+	s.SetTransform(matrix.Translation(0, 2, 0))
+	g.AddChild(p, s)
+	g.CalculateBoundingBoxCascade()
+	g.BuildBVH(DefaultLeafSize)
+
+	stats := g.BVH().Stats()
+	if stats.Unbounded != 1 || stats.Leaves != 1 {
+		t.Errorf("expected 1 unbounded shape and 1 leaf, got %s", stats)
+	}
+
+	r := ray.New(tuple.NewPoint(10, 5, 0), tuple.NewVector(0, -1, 0))
+	expected := Intersections{NewIntersection(5, p)}
+	testIntersection(t, g, r, expected)
+}
+
+func TestBVHNestedGroup(t *testing.T) {
+	// Transformed groups are placed in the tree by their bounds in the parent's space.
+	g := NewGroup()
+	inner := NewGroup()
+	inner.SetTransform(matrix.Translation(10, 0, 0))
+	s1 := NewSphere()
+	inner.AddChild(s1)
+	s2 := NewSphere()
+	s2.SetTransform(matrix.Translation(-10, 0, 0))
+	g.AddChild(inner, s2)
+	g.CalculateBoundingBoxCascade()
+	g.BuildBVH(1)
+
+	if inner.BVH() == nil {
+		t.Errorf("nested group did not build its hierarchy")
+	}
+
+	r := ray.New(tuple.NewPoint(10, 0, -5), tuple.NewVector(0, 0, 1))
+	expected := Intersections{NewIntersection(4, s1), NewIntersection(6, s1)}
+	testIntersection(t, g, r, expected)
+}
+
+func TestBVHStats(t *testing.T) {
+	g := sphereGrid(4)
+	g.BuildBVH(1)
+	stats := g.BVH().Stats()
+
+	if stats.Leaves != 16 || stats.MinLeafSize != 1 || stats.MaxLeafSize != 1 {
+		t.Errorf("expected 16 leaves with one sphere each, got %s", stats)
+	}
+	if stats.Nodes != 2*stats.Leaves-1 {
+		t.Errorf("expected %d nodes, got %s", 2*stats.Leaves-1, stats)
+	}
+	if stats.Depth < 5 {
+		t.Errorf("expected a depth of at least 5, got %s", stats)
+	}
+
+	empty := NewBVH([]Shape{}, DefaultLeafSize).Stats()
+	if empty.Nodes != 0 || empty.Leaves != 0 {
+		t.Errorf("expected an empty hierarchy, got %s", empty)
+	}
+}
+
+func TestAddChildDiscardsBVH(t *testing.T) {
+	g := sphereGrid(2)
+	g.BuildBVH(DefaultLeafSize)
+	g.AddChild(NewSphere())
+
+	if g.BVH() != nil {
+		t.Errorf("adding a child should discard the hierarchy")
+	}
+}
@@ -0,0 +1,161 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// Cone is a double-napped cone around the y axis, its tips meet at the origin and the radius at any height is |y|.
+// Minimum and maximum truncate it like a cylinder, a minimum of 0 leaves a single cone
+// and a minimum above 0 a truncated cone. Closed cones have caps.
+type Cone struct {
+	transform        matrix.Matrix
+	material         *materials.Material
+	Minimum, Maximum float64
+	Closed           bool
+	parent           Shape
+	boundingBox      *BoundingBox
+}
+
+func NewCone() *Cone {
+	return &Cone{
+		transform:   matrix.DefaultTransform(),
+		material:    materials.DefaultMaterial(),
+		Minimum:     math.Inf(-1),
+		Maximum:     math.Inf(1),
+		Closed:      false,
+		boundingBox: DefaultBoundingBox(),
+	}
+}
+
+func (s *Cone) String() string {
+	return fmt.Sprintf("Cone(min: %f, max: %f, transform: %s, material: %s)", s.Minimum, s.Maximum, s.transform, s.material)
+}
+
+func (s *Cone) Parent() Shape {
+	return s.parent
+}
+
+func (s *Cone) SetParent(other Shape) {
+	s.parent = other
+}
+
+func (s *Cone) SetTransform(transform matrix.Matrix) {
+	s.transform = transform
+}
+
+func (s *Cone) SetMaterial(mat *materials.Material) {
+	s.material = mat
+}
+
+func (s *Cone) Material() *materials.Material {
+	return s.material
+}
+
+func (s *Cone) Transform() matrix.Matrix {
+	return s.transform
+}
+
+func (s *Cone) CalculateBoundingBox() {
+	// the widest part is at the end farther from the tip.
+	limit := math.Max(math.Abs(s.Minimum), math.Abs(s.Maximum))
+	s.boundingBox.Min = tuple.NewPoint(-limit, s.Minimum, -limit)
+	s.boundingBox.Max = tuple.NewPoint(limit, s.Maximum, limit)
+
+	// The matrix transformation would result in Inf x 0 = NaN
+	if s.Minimum != math.Inf(-1) && s.Maximum != math.Inf(1) {
+		TransformBoundingBox(s.boundingBox, s.Transform())
+	}
+}
+
+func (s *Cone) BoundingBox() *BoundingBox {
+	return s.boundingBox
+}
+
+func (s *Cone) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	// compute the square of the distance from the y axis.
+	dist := math.Pow(point.X, 2) + math.Pow(point.Z, 2)
+
+	if dist < math.Pow(s.Maximum, 2) && point.Y >= s.Maximum-utils.EPSILON {
+		return tuple.NewVector(0, 1, 0)
+	} else if dist < math.Pow(s.Minimum, 2) && point.Y <= s.Minimum+utils.EPSILON {
+		return tuple.NewVector(0, -1, 0)
+	}
+
+	// the tip has no direction away from the axis, the normal points along it.
+	if dist < utils.EPSILON {
+		if point.Y > 0 {
+			return tuple.NewVector(0, -1, 0)
+		}
+		return tuple.NewVector(0, 1, 0)
+	}
+
+	// the side leans 45 degrees, away from the axis and towards the tip.
+	y := math.Sqrt(dist)
+	if point.Y > 0 {
+		y = -y
+	}
+	return tuple.NewVector(point.X, y, point.Z)
+}
+
+func (s *Cone) localIntersect(r *ray.Ray) Intersections {
+	a := math.Pow(r.Direction.X, 2) - math.Pow(r.Direction.Y, 2) + math.Pow(r.Direction.Z, 2)
+	b := 2*r.Origin.X*r.Direction.X - 2*r.Origin.Y*r.Direction.Y + 2*r.Origin.Z*r.Direction.Z
+	c := math.Pow(r.Origin.X, 2) - math.Pow(r.Origin.Y, 2) + math.Pow(r.Origin.Z, 2)
+
+	xs := Intersections{}
+	switch {
+	case utils.FloatEquals(a, 0) && utils.FloatEquals(b, 0):
+		// the ray runs along the side, it can only hit the caps.
+	case utils.FloatEquals(a, 0):
+		// the ray is parallel to one of the halves, it hits the other half once.
+		xs = s.appendIfWithinLimits(xs, r, -c/(2*b))
+	default:
+		discriminant := math.Pow(b, 2) - 4*a*c
+		if discriminant < 0 {
+			break
+		}
+
+		t0 := (-b - math.Sqrt(discriminant)) / (2 * a)
+		t1 := (-b + math.Sqrt(discriminant)) / (2 * a)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		xs = s.appendIfWithinLimits(xs, r, t0)
+		xs = s.appendIfWithinLimits(xs, r, t1)
+	}
+
+	return s.intersectionsForCaps(xs, r)
+}
+
+func (s *Cone) appendIfWithinLimits(xs Intersections, r *ray.Ray, t float64) Intersections {
+	y := r.Origin.Y + t*r.Direction.Y
+	if s.Minimum < y && y < s.Maximum {
+		return append(xs, NewIntersection(t, s))
+	}
+	return xs
+}
+
+func (s *Cone) intersectionsForCaps(xs Intersections, r *ray.Ray) Intersections {
+	// caps only matter if the cone is closed, and might possibly be intersected by the ray.
+	if !s.Closed || utils.FloatEquals(r.Direction.Y, 0) {
+		return xs
+	}
+
+	// the radius of a cap is the distance of its plane from the tip.
+	for _, y := range []float64{s.Minimum, s.Maximum} {
+		t := (y - r.Origin.Y) / r.Direction.Y
+		x := r.Origin.X + t*r.Direction.X
+		z := r.Origin.Z + t*r.Direction.Z
+		if math.Pow(x, 2)+math.Pow(z, 2) <= math.Pow(y, 2) {
+			xs = append(xs, NewIntersection(t, s))
+		}
+	}
+	return xs
+}
@@ -0,0 +1,230 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestConeLocalIntersect(t *testing.T) {
+	cone := NewCone()
+	var tests = []struct {
+		ray      *ray.Ray
+		expected []float64
+	}{
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1).Normalize()),
+			expected: []float64{5, 5},
+		},
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(1, 1, 1).Normalize()),
+			expected: []float64{8.660254037844386, 8.660254037844386},
+		},
+		{
+			ray:      ray.New(tuple.NewPoint(1, 1, -5), tuple.NewVector(-0.5, -1, 1).Normalize()),
+			expected: []float64{4.550055679356349, 49.449944320643645},
+		},
+		// parallel to one of the halves
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -1), tuple.NewVector(0, 1, 1).Normalize()),
+			expected: []float64{0.3535533905932738},
+		},
+		// along the side
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 1).Normalize()),
+			expected: []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		result := cone.localIntersect(test.ray)
+		if len(result) != len(test.expected) {
+			t.Errorf("incorrect number of intersections:\n%s\nresult: %d. expected: %d", test.ray, len(result), len(test.expected))
+			continue
+		}
+		for i := range result {
+			if !utils.FloatEquals(result[i].t, test.expected[i]) {
+				t.Errorf("incorrect t of intersect:\n%s\nresult: %f. expected: %f", test.ray, result[i].t, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestClosedConeIntersect(t *testing.T) {
+	cone := NewCone()
+	cone.Minimum = -0.5
+	cone.Maximum = 0.5
+	cone.Closed = true
+
+	var tests = []struct {
+		ray      *ray.Ray
+		expected int
+	}{
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 1, 0).Normalize()),
+			expected: 0,
+		},
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -0.25), tuple.NewVector(0, 1, 1).Normalize()),
+			expected: 2,
+		},
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -0.25), tuple.NewVector(0, 1, 0).Normalize()),
+			expected: 4,
+		},
+	}
+
+	for _, test := range tests {
+		result := cone.localIntersect(test.ray)
+		if len(result) != test.expected {
+			t.Errorf("incorrect number of intersections. Result: %d. Expected: %d", len(result), test.expected)
+		}
+	}
+}
+
+func TestTruncatedConeIntersect(t *testing.T) {
+	// a frustum, the caps have a radius of 1 and 2.
+	cone := NewCone()
+	cone.Minimum = 1
+	cone.Maximum = 2
+	cone.Closed = true
+
+	var tests = []struct {
+		ray      *ray.Ray
+		expected []float64
+	}{
+		// straight down through both caps
+		{
+			ray:      ray.New(tuple.NewPoint(0, 3, 0), tuple.NewVector(0, -1, 0)),
+			expected: []float64{1, 2},
+		},
+		// through the wider cap and the side
+		{
+			ray:      ray.New(tuple.NewPoint(1.5, 3, 0), tuple.NewVector(0, -1, 0)),
+			expected: []float64{1, 1.5},
+		},
+		// through the side at y = 1.5, below the smaller cap's radius
+		{
+			ray:      ray.New(tuple.NewPoint(-5, 1.5, 0), tuple.NewVector(1, 0, 0)),
+			expected: []float64{3.5, 6.5},
+		},
+		// the tip is cut off
+		{
+			ray:      ray.New(tuple.NewPoint(-5, 0.5, 0), tuple.NewVector(1, 0, 0)),
+			expected: []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		result := cone.localIntersect(test.ray)
+		result.Sort()
+		if len(result) != len(test.expected) {
+			t.Errorf("incorrect number of intersections:\n%s\nresult: %d. expected: %d", test.ray, len(result), len(test.expected))
+			continue
+		}
+		for i := range result {
+			if !utils.FloatEquals(result[i].t, test.expected[i]) {
+				t.Errorf("incorrect t of intersect:\n%s\nresult: %f. expected: %f", test.ray, result[i].t, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestConeLocalNormalAt(t *testing.T) {
+	cone := NewCone()
+	var tests = []struct {
+		point    tuple.Tuple
+		expected tuple.Tuple
+	}{
+		{
+			point:    tuple.NewPoint(0, 0, 0),
+			expected: tuple.NewVector(0, 1, 0),
+		},
+		{
+			point:    tuple.NewPoint(1, 1, 1),
+			expected: tuple.NewVector(1, -math.Sqrt2, 1),
+		},
+		{
+			point:    tuple.NewPoint(-1, -1, 0),
+			expected: tuple.NewVector(-1, 1, 0),
+		},
+	}
+
+	for _, test := range tests {
+		if result := cone.localNormalAt(test.point, Intersection{}); !result.Equal(test.expected) {
+			t.Errorf("Cone normal: \nresult: \n%s. \nexpected: \n%s", result, test.expected)
+		}
+	}
+}
+
+func TestConeNormalAtTip(t *testing.T) {
+	// A ray hitting the tip gets a usable normal
+	cone := NewCone()
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	xs := Intersect(cone, r)
+	if len(xs) == 0 {
+		t.Fatalf("expected the ray to hit the tip")
+	}
+	point := r.Position(xs[0].T())
+	normal := NormalAt(point, cone, xs[0])
+	if math.IsNaN(normal.X) || math.IsNaN(normal.Y) || math.IsNaN(normal.Z) || !utils.FloatEquals(normal.Magnitude(), 1) {
+		t.Errorf("expected a unit normal at the tip %s, got %s", point, normal)
+	}
+}
+
+func TestClosedConeLocalNormalAt(t *testing.T) {
+	cone := NewCone()
+	cone.Minimum = 1
+	cone.Maximum = 2
+	cone.Closed = true
+
+	var tests = []struct {
+		point    tuple.Tuple
+		expected tuple.Tuple
+	}{
+		{
+			point:    tuple.NewPoint(0.5, 1, 0),
+			expected: tuple.NewVector(0, -1, 0),
+		},
+		{
+			point:    tuple.NewPoint(0, 2, 1.5),
+			expected: tuple.NewVector(0, 1, 0),
+		},
+		// the side, not the smaller cap
+		{
+			point:    tuple.NewPoint(1, 1, 0),
+			expected: tuple.NewVector(1, -1, 0),
+		},
+	}
+
+	for _, test := range tests {
+		if result := cone.localNormalAt(test.point, Intersection{}); !result.Equal(test.expected) {
+			t.Errorf("Cone normal: \nresult: \n%s. \nexpected: \n%s", result, test.expected)
+		}
+	}
+}
+
+func TestBoundingBoxForCone(t *testing.T) {
+	c := NewCone()
+	c.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)), tuple.NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)))
+
+	for _, diff := range utils.Compare(c.BoundingBox(), expected) {
+		t.Errorf("Mismatch: %s", diff)
+	}
+}
+
+func TestBoundingBoxForLimitedCone(t *testing.T) {
+	c := NewCone()
+	c.Minimum = -5
+	c.Maximum = 3
+	c.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(-5, -5, -5), tuple.NewPoint(5, 3, 5))
+
+	for _, diff := range utils.Compare(c.BoundingBox(), expected) {
+		t.Errorf("Mismatch: %s", diff)
+	}
+}
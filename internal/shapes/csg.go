@@ -0,0 +1,167 @@
+package shapes
+
+import (
+	"fmt"
+
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Operation decides which parts of the two shapes a CSG shape keeps.
+type Operation int
+
+const (
+	CSGUnion        Operation = iota // both shapes, without the parts inside of each other.
+	CSGIntersection                  // only where the shapes overlap.
+	CSGDifference                    // the left shape, with the right shape cut out of it.
+)
+
+// CSG (constructive solid geometry) combines two shapes into one with an operation,
+// e.g. a hole is the difference of a cube and a cylinder. The children keep their own materials.
+type CSG struct {
+	operation   Operation
+	left, right Shape
+	transform   matrix.Matrix
+	parent      Shape
+	boundingBox *BoundingBox
+}
+
+func NewCSG(operation Operation, left, right Shape) *CSG {
+	c := &CSG{
+		operation:   operation,
+		left:        left,
+		right:       right,
+		transform:   matrix.DefaultTransform(),
+		boundingBox: DefaultBoundingBox(),
+	}
+	left.SetParent(c)
+	right.SetParent(c)
+	return c
+}
+
+func (c *CSG) String() string {
+	return fmt.Sprintf("CSG(operation: %d, left: %s, right: %s, transform: %s)", c.operation, c.left, c.right, c.transform)
+}
+
+func (c *CSG) Operation() Operation {
+	return c.operation
+}
+
+func (c *CSG) Left() Shape {
+	return c.left
+}
+
+func (c *CSG) Right() Shape {
+	return c.right
+}
+
+func (c *CSG) SetTransform(transform matrix.Matrix) {
+	c.transform = transform
+}
+
+func (c *CSG) SetMaterial(mat *materials.Material) {
+}
+
+func (c *CSG) Material() *materials.Material {
+	return materials.DefaultMaterial()
+}
+
+func (c *CSG) Transform() matrix.Matrix {
+	return c.transform
+}
+
+func (c *CSG) Parent() Shape {
+	return c.parent
+}
+
+func (c *CSG) SetParent(other Shape) {
+	c.parent = other
+}
+
+func (c *CSG) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	panic("localNormalAt called on CSG. The normals belong to the children")
+}
+
+// CalculateBoundingBox calculates the boxes of the children first.
+// The difference and the intersection never reach outside of the left shape.
+func (c *CSG) CalculateBoundingBox() {
+	c.boundingBox = DefaultBoundingBox()
+	for _, child := range []Shape{c.left, c.right} {
+		if g, ok := child.(*Group); ok {
+			g.CalculateBoundingBoxCascade()
+		} else {
+			child.CalculateBoundingBox()
+		}
+	}
+
+	c.boundingBox.AddBox(parentSpaceBox(c.left))
+	if c.operation == CSGUnion {
+		c.boundingBox.AddBox(parentSpaceBox(c.right))
+	}
+}
+
+func (c *CSG) BoundingBox() *BoundingBox {
+	return c.boundingBox
+}
+
+func (c *CSG) localIntersect(r *ray.Ray) Intersections {
+	if !BoxIntersection(c.boundingBox, r) {
+		return Intersections{}
+	}
+
+	// the rules need every intersection of the children, to know when the ray is inside of them.
+	xs := append(IntersectAll(c.left, r), IntersectAll(c.right, r)...)
+	xs.Sort()
+	return c.filter(xs)
+}
+
+// The result of a CSG shape is complete, nothing is skipped.
+func (c *CSG) localIntersectAll(r *ray.Ray) Intersections {
+	return c.localIntersect(r)
+}
+
+// Keeps the intersections that are on the surface of the combined shape. Walking the sorted intersections
+// the ray enters and leaves the children, whether a hit is kept depends on which child it belongs to
+// and whether the ray is inside of the other child.
+func (c *CSG) filter(xs Intersections) Intersections {
+	result := Intersections{}
+	var inLeft, inRight bool
+	for _, i := range xs {
+		leftHit := includes(c.left, i.shape)
+		if c.allowed(leftHit, inLeft, inRight) {
+			result = append(result, i)
+		}
+
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+	return result
+}
+
+func (c *CSG) allowed(leftHit, inLeft, inRight bool) bool {
+	switch c.operation {
+	case CSGUnion:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case CSGIntersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case CSGDifference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	default:
+		return false
+	}
+}
+
+// Reports whether the shape is the container or one of its descendants.
+func includes(container, shape Shape) bool {
+	for s := shape; s != nil; s = s.Parent() {
+		if s == container {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+func TestNewCSG(t *testing.T) {
+	s1, s2 := NewSphere(), NewCube()
+	c := NewCSG(CSGUnion, s1, s2)
+
+	if c.Left() != s1 || c.Right() != s2 {
+		t.Errorf("expected the children to be the sphere and the cube")
+	}
+	if s1.Parent() != c || s2.Parent() != c {
+		t.Errorf("expected the CSG to be the parent of its children")
+	}
+}
+
+func TestCSGAllowed(t *testing.T) {
+	var tests = []struct {
+		operation                Operation
+		leftHit, inLeft, inRight bool
+		expected                 bool
+	}{
+		{CSGUnion, true, true, true, false},
+		{CSGUnion, true, true, false, true},
+		{CSGUnion, true, false, true, false},
+		{CSGUnion, true, false, false, true},
+		{CSGUnion, false, true, true, false},
+		{CSGUnion, false, true, false, false},
+		{CSGUnion, false, false, true, true},
+		{CSGUnion, false, false, false, true},
+		{CSGIntersection, true, true, true, true},
+		{CSGIntersection, true, true, false, false},
+		{CSGIntersection, true, false, true, true},
+		{CSGIntersection, true, false, false, false},
+		{CSGIntersection, false, true, true, true},
+		{CSGIntersection, false, true, false, true},
+		{CSGIntersection, false, false, true, false},
+		{CSGIntersection, false, false, false, false},
+		{CSGDifference, true, true, true, false},
+		{CSGDifference, true, true, false, true},
+		{CSGDifference, true, false, true, false},
+		{CSGDifference, true, false, false, true},
+		{CSGDifference, false, true, true, true},
+		{CSGDifference, false, true, false, true},
+		{CSGDifference, false, false, true, false},
+		{CSGDifference, false, false, false, false},
+	}
+
+	for _, test := range tests {
+		c := NewCSG(test.operation, NewSphere(), NewCube())
+		if result := c.allowed(test.leftHit, test.inLeft, test.inRight); result != test.expected {
+			t.Errorf("operation %d, left hit: %t, in left: %t, in right: %t: expected %t, got %t",
+				test.operation, test.leftHit, test.inLeft, test.inRight, test.expected, result)
+		}
+	}
+}
+
+func TestCSGFilter(t *testing.T) {
+	var tests = []struct {
+		operation Operation
+		expected  []int
+	}{
+		{CSGUnion, []int{0, 3}},
+		{CSGIntersection, []int{1, 2}},
+		{CSGDifference, []int{0, 1}},
+	}
+
+	for _, test := range tests {
+		s1, s2 := NewSphere(), NewCube()
+		c := NewCSG(test.operation, s1, s2)
+		xs := Intersections{
+			NewIntersection(1, s1),
+			NewIntersection(2, s2),
+			NewIntersection(3, s1),
+			NewIntersection(4, s2),
+		}
+
+		result := c.filter(xs)
+		if len(result) != len(test.expected) {
+			t.Errorf("operation %d: expected %d intersections, got %d", test.operation, len(test.expected), len(result))
+			continue
+		}
+		for i, index := range test.expected {
+			if result[i] != xs[index] {
+				t.Errorf("operation %d: expected intersection %d to be %f, got %f", test.operation, i, xs[index].t, result[i].t)
+			}
+		}
+	}
+}
+
+func TestCSGIntersect(t *testing.T) {
+	// A ray misses a CSG object
+	c := NewCSG(CSGUnion, NewSphere(), NewCube())
+	c.CalculateBoundingBox()
+	if xs := Intersect(c, ray.New(tuple.NewPoint(0, 2, -5), tuple.NewVector(0, 0, 1))); len(xs) != 0 {
+		t.Errorf("expected no intersections, got %s", xs)
+	}
+
+	// A ray hits a CSG object
+	s1, s2 := NewSphere(), NewSphere()
+	s2.SetTransform(matrix.Translation(0, 0, 0.5))
+	c = NewCSG(CSGUnion, s1, s2)
+	c.CalculateBoundingBox()
+	xs := Intersect(c, ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)))
+	if len(xs) != 2 || xs[0].t != 4 || xs[0].shape != s1 || xs[1].t != 6.5 || xs[1].shape != s2 {
+		t.Errorf("expected intersections at 4 and 6.5, got %s", xs)
+	}
+}
+
+func TestCSGWithHierarchy(t *testing.T) {
+	// Holes cut with a group of spheres, the hierarchy of the group must not skip any of them
+	block := NewCube()
+	block.SetTransform(matrix.Scaling(3, 1, 1))
+	holes := NewGroup()
+	for _, x := range []float64{-2, 0, 2} {
+		sphere := NewSphere()
+		sphere.SetTransform(matrix.Multiply(matrix.Translation(x, 0, 0), matrix.Scaling(0.5, 0.5, 0.5)))
+		holes.AddChild(sphere)
+	}
+	holes.CalculateBoundingBoxCascade()
+	holes.BuildBVH(1)
+
+	c := NewCSG(CSGDifference, block, holes)
+	c.CalculateBoundingBox()
+	// the CSG works inside groups and other CSG shapes too
+	outer := NewGroup()
+	outer.AddChild(NewCSG(CSGUnion, c, NewSphere()))
+	outer.CalculateBoundingBoxCascade()
+	outer.BuildBVH(1)
+
+	xs := Intersect(outer, ray.New(tuple.NewPoint(-10, 0.1, 0), tuple.NewVector(1, 0, 0)))
+	if len(xs) != 6 {
+		t.Fatalf("expected 6 intersections, got %d: %s", len(xs), xs)
+	}
+	if xs[0].shape != block || xs[len(xs)-1].shape != block {
+		t.Errorf("expected the ray to enter and leave through the block, got %s and %s", xs[0].shape, xs[len(xs)-1].shape)
+	}
+
+	// the hits keep the normals of the shapes that made them
+	normal := NormalAt(tuple.NewPoint(-2.5, 0, 0), xs[1].shape, xs[1])
+	if !normal.Equal(tuple.NewVector(-1, 0, 0)) {
+		t.Errorf("expected the normal of the hole to be %s, got %s", tuple.NewVector(-1, 0, 0), normal)
+	}
+}
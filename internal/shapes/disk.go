@@ -0,0 +1,91 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// Disk is a flat circle of radius 1 in the xz plane, facing up.
+// With an InnerRadius above 0 it has a hole in the middle, like a washer.
+type Disk struct {
+	transform   matrix.Matrix
+	material    *materials.Material
+	InnerRadius float64
+	parent      Shape
+	boundingBox *BoundingBox
+}
+
+func NewDisk() *Disk {
+	return &Disk{
+		transform:   matrix.DefaultTransform(),
+		material:    materials.DefaultMaterial(),
+		boundingBox: DefaultBoundingBox(),
+	}
+}
+
+func (s *Disk) String() string {
+	return fmt.Sprintf("Disk(inner radius: %f, transform: %s, material: %s)", s.InnerRadius, s.transform, s.material)
+}
+
+func (s *Disk) Parent() Shape {
+	return s.parent
+}
+
+func (s *Disk) SetParent(other Shape) {
+	s.parent = other
+}
+
+func (s *Disk) SetTransform(transform matrix.Matrix) {
+	s.transform = transform
+}
+
+func (s *Disk) SetMaterial(mat *materials.Material) {
+	s.material = mat
+}
+
+func (s *Disk) Material() *materials.Material {
+	return s.material
+}
+
+func (s *Disk) Transform() matrix.Matrix {
+	return s.transform
+}
+
+func (s *Disk) CalculateBoundingBox() {
+	s.boundingBox.Min = tuple.NewPoint(-1, 0, -1)
+	s.boundingBox.Max = tuple.NewPoint(1, 0, 1)
+
+	TransformBoundingBox(s.boundingBox, s.Transform())
+}
+
+func (s *Disk) BoundingBox() *BoundingBox {
+	return s.boundingBox
+}
+
+func (s *Disk) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	return tuple.NewVector(0, 1, 0)
+}
+
+func (s *Disk) localIntersect(r *ray.Ray) Intersections {
+	if math.Abs(r.Direction.Y) < utils.EPSILON {
+		return Intersections{}
+	}
+
+	t := -r.Origin.Y / r.Direction.Y
+	x := r.Origin.X + t*r.Direction.X
+	z := r.Origin.Z + t*r.Direction.Z
+	dist := math.Pow(x, 2) + math.Pow(z, 2)
+	if dist > 1 || dist < math.Pow(s.InnerRadius, 2) {
+		return Intersections{}
+	}
+
+	return Intersections{
+		NewIntersection(t, s),
+	}
+}
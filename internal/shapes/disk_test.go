@@ -0,0 +1,78 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestDiskLocalIntersect(t *testing.T) {
+	disk := NewDisk()
+	washer := NewDisk()
+	washer.InnerRadius = 0.5
+
+	var tests = []struct {
+		disk     *Disk
+		ray      *ray.Ray
+		expected Intersections
+	}{
+		{
+			disk:     disk,
+			ray:      ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{NewIntersection(1, disk)},
+		},
+		{
+			disk:     disk,
+			ray:      ray.New(tuple.NewPoint(0.6, -2, 0.6), tuple.NewVector(0, 1, 0)),
+			expected: Intersections{NewIntersection(2, disk)},
+		},
+		// outside of the radius
+		{
+			disk:     disk,
+			ray:      ray.New(tuple.NewPoint(0.8, 1, 0.8), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{},
+		},
+		// parallel
+		{
+			disk:     disk,
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)),
+			expected: Intersections{},
+		},
+		// through the hole
+		{
+			disk:     washer,
+			ray:      ray.New(tuple.NewPoint(0.3, 1, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{},
+		},
+		{
+			disk:     washer,
+			ray:      ray.New(tuple.NewPoint(0.7, 1, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{NewIntersection(1, washer)},
+		},
+	}
+
+	for _, test := range tests {
+		testIntersection(t, test.disk, test.ray, test.expected)
+	}
+}
+
+func TestDiskLocalNormalAt(t *testing.T) {
+	disk := NewDisk()
+	for _, point := range []tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(0.5, 0, -0.5)} {
+		if result := disk.localNormalAt(point, Intersection{}); !result.Equal(tuple.NewVector(0, 1, 0)) {
+			t.Errorf("Disk normal at %s: %s", point, result)
+		}
+	}
+}
+
+func TestBoundingBoxForDisk(t *testing.T) {
+	disk := NewDisk()
+	disk.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(-1, 0, -1), tuple.NewPoint(1, 0, 1))
+
+	for _, diff := range utils.Compare(disk.BoundingBox(), expected) {
+		t.Errorf("Mismatch: %s", diff)
+	}
+}
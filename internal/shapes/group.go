@@ -14,6 +14,7 @@ type Group struct {
 	parent      Shape
 	children    []Shape
 	boundingBox *BoundingBox
+	bvh         *BVH
 }
 
 func (g *Group) String() string {
@@ -40,13 +41,28 @@ func (g *Group) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple
 }
 
 func (g *Group) localIntersect(r *ray.Ray) Intersections {
+	return g.intersectChildren(r, false)
+}
+
+// Returns every intersection of the children, even the ones the hierarchy would skip.
+func (g *Group) localIntersectAll(r *ray.Ray) Intersections {
+	return g.intersectChildren(r, true)
+}
+
+func (g *Group) intersectChildren(r *ray.Ray, all bool) Intersections {
 	if !BoxIntersection(g.boundingBox, r) {
 		return Intersections{}
 	}
 
+	if g.bvh != nil {
+		xs := g.bvh.traverse(r, all)
+		xs.Sort()
+		return xs
+	}
+
 	xs := Intersections{}
 	for i := 0; i < len(g.children); i++ {
-		xs = append(xs, Intersect(g.children[i], r)...)
+		xs = append(xs, intersect(g.children[i], r, all)...)
 	}
 	xs.Sort()
 	return xs
@@ -73,10 +89,12 @@ func (g *Group) AddChild(shapes ...Shape) {
 		shapes[i].SetParent(g)
 	}
 	g.children = append(g.children, shapes...)
+	g.bvh = nil
 }
 
 func (g *Group) RemoveChild(s Shape) {
 	s.SetParent(nil)
+	g.bvh = nil
 	for i := 0; i < len(g.children); i++ {
 		if g.children[i] == s {
 			// replace the child with the last element
@@ -110,46 +128,26 @@ func (g *Group) BoundingBox() *BoundingBox {
 	return g.boundingBox
 }
 
-func (g *Group) Partition() (left, right []Shape) {
-	leftBox, _ := g.boundingBox.Split()
-
+// BuildBVH organises the children into a bounding volume hierarchy, which speeds up the intersections.
+// Nested groups and models build their own hierarchies first. The bounding boxes have to be calculated beforehand.
+// Adding or removing children discards the hierarchy.
+func (g *Group) BuildBVH(leafSize int) {
 	for i := 0; i < len(g.children); i++ {
-		if leftBox.ContainsBox(g.children[i].BoundingBox()) {
-			left = append(left, g.children[i])
-		} else {
-			right = append(right, g.children[i])
+		switch child := g.children[i].(type) {
+		case *Group:
+			if child.bvh == nil {
+				child.BuildBVH(leafSize)
+			}
+		case *Model:
+			if child.group.bvh == nil {
+				child.BuildBVH(leafSize)
+			}
 		}
 	}
-	return left, right
+	g.bvh = NewBVH(g.children, leafSize)
 }
 
-func (g *Group) Divide(threshold int) {
-	// TODO: recursively check each children and if a group, sum it's children as well.
-	// models can have thousands of children and it's not reflected on the parent groups.
-	if len(g.children) >= threshold {
-		left, right := g.Partition()
-
-		if len(left) > 0 && len(right) > 0 {
-			g.children = []Shape{}
-
-			leftGroup := NewGroup()
-			leftGroup.AddChild(left...)
-			leftGroup.CalculateBoundingBox()
-			g.AddChild(leftGroup)
-
-			rightGroup := NewGroup()
-			rightGroup.AddChild(right...)
-			rightGroup.CalculateBoundingBox()
-			g.AddChild(rightGroup)
-		}
-
-	}
-	for i := 0; i < len(g.children); i++ {
-		if group, ok := g.children[i].(*Group); ok {
-			group.Divide(threshold)
-		}
-		if model, ok := g.children[i].(*Model); ok {
-			model.Divide(threshold)
-		}
-	}
+// BVH returns the hierarchy of the group, nil if it was not built.
+func (g *Group) BVH() *BVH {
+	return g.bvh
 }
@@ -75,70 +75,6 @@ func TestRemoveChild(t *testing.T) {
 	}
 }
 
-func TestPartition(t *testing.T) {
-	g := NewGroup()
-	s1 := NewSphere()
-	s1.SetTransform(matrix.Translation(-2, 0, 0))
-	s2 := NewSphere()
-	s2.SetTransform(matrix.Translation(2, 0, 0))
-	s3 := NewSphere()
-	g.AddChild(s1)
-	g.AddChild(s2)
-	g.AddChild(s3)
-	g.CalculateBoundingBoxCascade()
-	left, right := g.Partition()
-
-	if left[0] != s1 {
-		t.Errorf("incorrect partition for left, expected \n%v\n, got \n%v\n", s1, left[0])
-	}
-	if right[0] != s2 {
-		t.Errorf("incorrect partition for right, expected \n%v\n, got \n%v\n", s2, right[0])
-	}
-	if right[1] != s3 {
-		t.Errorf("incorrect partition for right, expected \n%v\n, got \n%v", s3, right[1])
-	}
-}
-
-func TestDivide(t *testing.T) {
-	// dividing a group partitions its children
-	g := NewGroup()
-	s1 := NewSphere()
-	s1.SetTransform(matrix.Translation(-2, -2, 0))
-	s2 := NewSphere()
-	s2.SetTransform(matrix.Translation(-2, 2, 0))
-	s3 := NewSphere()
-	s3.SetTransform(matrix.Scaling(4, 4, 4))
-	g.AddChild(s1, s2, s3)
-	g.CalculateBoundingBoxCascade()
-	g.Divide(1)
-
-	if len(g.Children()) != 2 {
-		t.Errorf("incorrect number of children, expected 2, got %v", len(g.Children()))
-	}
-
-	subGroup := g.Children()[0].(*Group)
-	if len(subGroup.Children()) != 2 {
-		t.Errorf("incorrect number of children, expected 2, got %v", len(subGroup.Children()))
-	}
-	subGroupOfS1 := subGroup.Children()[0].(*Group)
-	if len(subGroupOfS1.Children()) != 1 && subGroupOfS1.Children()[0] != s1 {
-		t.Errorf("incorrect children, expected %v, got %v", s1, subGroupOfS1.Children())
-	}
-	subGroupOfS2 := subGroup.Children()[1].(*Group)
-	if len(subGroupOfS2.Children()) != 1 && subGroupOfS2.Children()[0] != s2 {
-		t.Errorf("incorrect children, expected %v, got %v", s2, subGroupOfS2.Children())
-	}
-
-	subGroup2 := g.Children()[1].(*Group)
-	if len(subGroup2.Children()) != 1 {
-		t.Errorf("incorrect number of children, expected 2, got %v", len(subGroup2.Children()))
-	}
-	if len(subGroup2.Children()) != 1 && subGroup2.Children()[0] != s3 {
-		t.Errorf("incorrect children, expected %v, got %v", s3, subGroup2.Children())
-	}
-
-}
-
 func TestBoundingBoxForGroups(t *testing.T) {
 	// A group has a bounding box that contains its children
 	s1 := NewSphere()
@@ -0,0 +1,282 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// Heightfield is a terrain over the square from -1 to 1 on the x and z axes, like a quad.
+// The heights are sampled on a regular grid, heights[x][z], and every cell is split into two triangles.
+// The cells are walked along the ray with a 2D DDA, no triangles are allocated.
+type Heightfield struct {
+	transform   matrix.Matrix
+	material    *materials.Material
+	heights     [][]float64
+	normals     [][]tuple.Tuple // the vertex normals, interpolated for smooth shading.
+	bounds      *BoundingBox    // the local bounds, for entering the grid.
+	parent      Shape
+	boundingBox *BoundingBox
+}
+
+// NewHeightfield needs at least 2 x 2 heights.
+func NewHeightfield(heights [][]float64) *Heightfield {
+	if len(heights) < 2 || len(heights[0]) < 2 {
+		panic("Heightfield needs at least 2 x 2 heights")
+	}
+
+	s := &Heightfield{
+		transform:   matrix.DefaultTransform(),
+		material:    materials.DefaultMaterial(),
+		heights:     heights,
+		bounds:      DefaultBoundingBox(),
+		boundingBox: DefaultBoundingBox(),
+	}
+	for x := range heights {
+		for z := range heights[x] {
+			s.bounds.AddPoint(s.vertex(x, z))
+		}
+	}
+	s.calculateNormals()
+	return s
+}
+
+// HeightsFromImage uses the brightness of the pixels as heights, the top of the image is at z = 1.
+func HeightsFromImage(image canvas.Canvas) [][]float64 {
+	width, depth := len(image), len(image[0])
+	heights := make([][]float64, width)
+	for x := range heights {
+		heights[x] = make([]float64, depth)
+		for z := range heights[x] {
+			c := image[x][depth-1-z]
+			heights[x][z] = (c.R + c.G + c.B) / 3
+		}
+	}
+	return heights
+}
+
+// HeightsFromNoise samples the noise on a resolution x resolution grid, the heights are between 0 and 1.
+// The frequency is the number of noise units across the grid.
+func HeightsFromNoise(noise *materials.Noise, resolution int, frequency float64) [][]float64 {
+	heights := make([][]float64, resolution)
+	for x := range heights {
+		heights[x] = make([]float64, resolution)
+		for z := range heights[x] {
+			point := tuple.NewPoint(
+				float64(x)/float64(resolution-1)*frequency,
+				0,
+				float64(z)/float64(resolution-1)*frequency,
+			)
+			heights[x][z] = (noise.FBM(point) + 1) / 2
+		}
+	}
+	return heights
+}
+
+func (s *Heightfield) String() string {
+	return fmt.Sprintf("Heightfield(size: %dx%d, transform: %s, material: %s)", len(s.heights), len(s.heights[0]), s.transform, s.material)
+}
+
+func (s *Heightfield) Parent() Shape {
+	return s.parent
+}
+
+func (s *Heightfield) SetParent(other Shape) {
+	s.parent = other
+}
+
+func (s *Heightfield) SetTransform(transform matrix.Matrix) {
+	s.transform = transform
+}
+
+func (s *Heightfield) SetMaterial(mat *materials.Material) {
+	s.material = mat
+}
+
+func (s *Heightfield) Material() *materials.Material {
+	return s.material
+}
+
+func (s *Heightfield) Transform() matrix.Matrix {
+	return s.transform
+}
+
+func (s *Heightfield) CalculateBoundingBox() {
+	s.boundingBox.Min = s.bounds.Min
+	s.boundingBox.Max = s.bounds.Max
+
+	TransformBoundingBox(s.boundingBox, s.Transform())
+}
+
+func (s *Heightfield) BoundingBox() *BoundingBox {
+	return s.boundingBox
+}
+
+// the size of a cell along x and z.
+func (s *Heightfield) cellSize() (float64, float64) {
+	return 2 / float64(len(s.heights)-1), 2 / float64(len(s.heights[0])-1)
+}
+
+func (s *Heightfield) vertex(x, z int) tuple.Tuple {
+	cellX, cellZ := s.cellSize()
+	return tuple.NewPoint(-1+float64(x)*cellX, s.heights[x][z], -1+float64(z)*cellZ)
+}
+
+// The slopes are central differences of the neighbouring heights, one sided at the edges.
+func (s *Heightfield) calculateNormals() {
+	cellX, cellZ := s.cellSize()
+	width, depth := len(s.heights), len(s.heights[0])
+	s.normals = make([][]tuple.Tuple, width)
+	for x := range s.normals {
+		s.normals[x] = make([]tuple.Tuple, depth)
+		for z := range s.normals[x] {
+			left, right := max(x-1, 0), min(x+1, width-1)
+			back, front := max(z-1, 0), min(z+1, depth-1)
+			slopeX := (s.heights[right][z] - s.heights[left][z]) / (float64(right-left) * cellX)
+			slopeZ := (s.heights[x][front] - s.heights[x][back]) / (float64(front-back) * cellZ)
+			s.normals[x][z] = tuple.NewVector(-slopeX, 1, -slopeZ).Normalize()
+		}
+	}
+}
+
+// The vertex normals of the cell are blended by the position of the point in the cell.
+func (s *Heightfield) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	cellX, cellZ := s.cellSize()
+	gridX, gridZ := (point.X+1)/cellX, (point.Z+1)/cellZ
+	x := min(max(int(math.Floor(gridX)), 0), len(s.heights)-2)
+	z := min(max(int(math.Floor(gridZ)), 0), len(s.heights[0])-2)
+	fx, fz := clampUnit(gridX-float64(x)), clampUnit(gridZ-float64(z))
+
+	back := tuple.Add(s.normals[x][z].Scalar(1-fx), s.normals[x+1][z].Scalar(fx))
+	front := tuple.Add(s.normals[x][z+1].Scalar(1-fx), s.normals[x+1][z+1].Scalar(fx))
+	return tuple.Add(back.Scalar(1-fz), front.Scalar(fz))
+}
+
+// Stops after the first cell with a hit in front of the origin, the cells are visited in order.
+func (s *Heightfield) localIntersect(r *ray.Ray) Intersections {
+	return s.traverse(r, false)
+}
+
+func (s *Heightfield) localIntersectAll(r *ray.Ray) Intersections {
+	return s.traverse(r, true)
+}
+
+// Walks the cells under the ray with a 2D DDA (Amanatides and Woo), from where the ray enters the bounds.
+func (s *Heightfield) traverse(r *ray.Ray, all bool) Intersections {
+	xs := Intersections{}
+	inverse := tuple.NewVector(1/r.Direction.X, 1/r.Direction.Y, 1/r.Direction.Z)
+	near, far, ok := s.bounds.slabs(r.Origin, inverse)
+	if !ok {
+		return xs
+	}
+
+	cellX, cellZ := s.cellSize()
+	width, depth := len(s.heights), len(s.heights[0])
+	entry := r.Position(near)
+	x := min(max(int(math.Floor((entry.X+1)/cellX)), 0), width-2)
+	z := min(max(int(math.Floor((entry.Z+1)/cellZ)), 0), depth-2)
+
+	stepX, nextX, deltaX := s.dda(x, r.Origin.X, r.Direction.X, cellX)
+	stepZ, nextZ, deltaZ := s.dda(z, r.Origin.Z, r.Direction.Z, cellZ)
+
+	t := near
+	for x >= 0 && x < width-1 && z >= 0 && z < depth-1 {
+		exit := math.Min(math.Min(nextX, nextZ), far)
+		for _, hit := range s.intersectCell(r, x, z, t, exit) {
+			// a hit on the border of two cells is found in both of them.
+			if len(xs) > 0 && math.Abs(xs[len(xs)-1].t-hit) < utils.EPSILON {
+				continue
+			}
+			xs = append(xs, NewIntersection(hit, s))
+		}
+		if exit >= far || (!all && closestHit(xs, math.Inf(1)) <= exit) {
+			break
+		}
+
+		if nextX < nextZ {
+			x += stepX
+			t = nextX
+			nextX += deltaX
+		} else {
+			z += stepZ
+			t = nextZ
+			nextZ += deltaZ
+		}
+	}
+	return xs
+}
+
+// returns the direction of the steps along the axis, where the ray crosses into the next cell and
+// how far apart the crossings are.
+func (s *Heightfield) dda(cell int, origin, direction, size float64) (int, float64, float64) {
+	switch {
+	case direction > 0:
+		border := -1 + float64(cell+1)*size
+		return 1, (border - origin) / direction, size / direction
+	case direction < 0:
+		border := -1 + float64(cell)*size
+		return -1, (border - origin) / direction, -size / direction
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// The hits with the two triangles of the cell, between the entry and the exit of the ray.
+func (s *Heightfield) intersectCell(r *ray.Ray, x, z int, entry, exit float64) []float64 {
+	a, b, c, d := s.vertex(x, z), s.vertex(x+1, z), s.vertex(x, z+1), s.vertex(x+1, z+1)
+
+	// the ray passes over or under the whole cell.
+	low := math.Min(math.Min(a.Y, b.Y), math.Min(c.Y, d.Y))
+	high := math.Max(math.Max(a.Y, b.Y), math.Max(c.Y, d.Y))
+	entryY, exitY := r.Origin.Y+entry*r.Direction.Y, r.Origin.Y+exit*r.Direction.Y
+	if math.Min(entryY, exitY) > high+utils.EPSILON || math.Max(entryY, exitY) < low-utils.EPSILON {
+		return nil
+	}
+
+	hits := []float64{}
+	for _, triangle := range [2][3]tuple.Tuple{{a, b, d}, {a, d, c}} {
+		t, ok := intersectTriangle(r, triangle[0], triangle[1], triangle[2])
+		if ok && t >= entry-utils.EPSILON && t <= exit+utils.EPSILON {
+			hits = append(hits, t)
+		}
+	}
+	if len(hits) == 2 && hits[1] < hits[0] {
+		hits[0], hits[1] = hits[1], hits[0]
+	}
+	return hits
+}
+
+// Möller–Trumbore, like the triangles of models.
+func intersectTriangle(r *ray.Ray, p1, p2, p3 tuple.Tuple) (float64, bool) {
+	e1, e2 := tuple.Subtract(p2, p1), tuple.Subtract(p3, p1)
+	directionCrossE2 := tuple.Cross(r.Direction, e2)
+	determinant := tuple.Dot(e1, directionCrossE2)
+	if math.Abs(determinant) < utils.EPSILON {
+		return 0, false
+	}
+
+	f := 1.0 / determinant
+	p1ToOrigin := tuple.Subtract(r.Origin, p1)
+	u := f * tuple.Dot(p1ToOrigin, directionCrossE2)
+	if u < 0.0 || u > 1.0 {
+		return 0, false
+	}
+
+	originCrossE1 := tuple.Cross(p1ToOrigin, e1)
+	v := f * tuple.Dot(r.Direction, originCrossE1)
+	if v < 0.0 || (u+v) > 1.0 {
+		return 0, false
+	}
+
+	return f * tuple.Dot(e2, originCrossE1), true
+}
+
+func clampUnit(x float64) float64 {
+	return math.Min(math.Max(x, 0), 1)
+}
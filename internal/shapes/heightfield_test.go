@@ -0,0 +1,171 @@
+package shapes
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/canvas"
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestHeightfieldIntersect(t *testing.T) {
+	flat := NewHeightfield([][]float64{{0.5, 0.5, 0.5}, {0.5, 0.5, 0.5}, {0.5, 0.5, 0.5}})
+	slope := NewHeightfield([][]float64{{0, 0}, {1, 1}})
+	// a ridge along the z axis, the height is 1 - |x|.
+	ridge := NewHeightfield([][]float64{{0, 0}, {1, 1}, {0, 0}})
+
+	var tests = []struct {
+		name     string
+		shape    *Heightfield
+		ray      *ray.Ray
+		all      bool
+		expected []float64
+	}{
+		{
+			name:     "flat",
+			shape:    flat,
+			ray:      ray.New(tuple.NewPoint(0.3, 5, 0.2), tuple.NewVector(0, -1, 0)),
+			expected: []float64{4.5},
+		},
+		{
+			name:     "outside of the grid",
+			shape:    flat,
+			ray:      ray.New(tuple.NewPoint(1.5, 5, 0.2), tuple.NewVector(0, -1, 0)),
+			expected: []float64{},
+		},
+		{
+			name:     "slope",
+			shape:    slope,
+			ray:      ray.New(tuple.NewPoint(0, 5, -0.7), tuple.NewVector(0, -2, 0)),
+			expected: []float64{2.25},
+		},
+		{
+			name:     "up the slope",
+			shape:    slope,
+			ray:      ray.New(tuple.NewPoint(-5, 0.75, 0.3), tuple.NewVector(1, 0, 0)),
+			expected: []float64{5.5},
+		},
+		{
+			name:     "the closest cell",
+			shape:    ridge,
+			ray:      ray.New(tuple.NewPoint(-5, 0.5, 0.3), tuple.NewVector(1, 0, 0)),
+			expected: []float64{4.5},
+		},
+		{
+			name:     "every cell",
+			shape:    ridge,
+			ray:      ray.New(tuple.NewPoint(-5, 0.5, 0.3), tuple.NewVector(1, 0, 0)),
+			all:      true,
+			expected: []float64{4.5, 5.5},
+		},
+		{
+			name:     "over the ridge",
+			shape:    ridge,
+			ray:      ray.New(tuple.NewPoint(-5, 1.5, 0.3), tuple.NewVector(1, 0, 0)),
+			all:      true,
+			expected: []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		result := test.shape.localIntersect(test.ray)
+		if test.all {
+			result = test.shape.localIntersectAll(test.ray)
+		}
+		if len(result) != len(test.expected) {
+			t.Errorf("%s: incorrect number of intersections. Result: %d. Expected: %d", test.name, len(result), len(test.expected))
+			continue
+		}
+		for i := range result {
+			if !utils.FloatEquals(result[i].t, test.expected[i]) {
+				t.Errorf("%s: incorrect t of intersect. Result: %f. Expected: %f", test.name, result[i].t, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestHeightfieldTraversalMatchesEveryTriangle(t *testing.T) {
+	shape := NewHeightfield(HeightsFromNoise(materials.DefaultNoise(), 16, 3))
+	random := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 200; i++ {
+		origin := tuple.NewPoint(random.Float64()*6-3, random.Float64()*3, random.Float64()*6-3)
+		target := tuple.NewPoint(random.Float64()*2-1, random.Float64(), random.Float64()*2-1)
+		r := ray.New(origin, tuple.Subtract(target, origin))
+
+		expected := []float64{}
+		for x := 0; x < len(shape.heights)-1; x++ {
+			for z := 0; z < len(shape.heights[0])-1; z++ {
+				expected = append(expected, shape.intersectCell(r, x, z, math.Inf(-1), math.Inf(1))...)
+			}
+		}
+
+		result := shape.localIntersectAll(r)
+		if len(result) != len(expected) {
+			t.Errorf("%s: incorrect number of intersections. Result: %d. Expected: %d", r, len(result), len(expected))
+			continue
+		}
+
+		result.Sort()
+		closest := closestHit(result, math.Inf(1))
+		if first := closestHit(shape.localIntersect(r), math.Inf(1)); first != closest {
+			t.Errorf("%s: the closest hit is %f, not %f", r, first, closest)
+		}
+	}
+}
+
+func TestHeightfieldLocalNormalAt(t *testing.T) {
+	slope := NewHeightfield([][]float64{{0, 0}, {1, 1}})
+	expected := tuple.NewVector(-0.5, 1, 0).Normalize()
+	for _, point := range []tuple.Tuple{tuple.NewPoint(0, 0.5, 0), tuple.NewPoint(-0.9, 0.05, 0.9)} {
+		if result := slope.localNormalAt(point, Intersection{}).Normalize(); !result.Equal(expected) {
+			t.Errorf("Heightfield normal at %s: \nresult: \n%s. \nexpected: \n%s", point, result, expected)
+		}
+	}
+
+	// the normals are blended between the two sides of a ridge.
+	ridge := NewHeightfield([][]float64{{0, 0}, {1, 1}, {0, 0}})
+	if result := ridge.localNormalAt(tuple.NewPoint(0, 1, 0), Intersection{}).Normalize(); !result.Equal(tuple.NewVector(0, 1, 0)) {
+		t.Errorf("Heightfield normal on the ridge: %s", result)
+	}
+	left := ridge.localNormalAt(tuple.NewPoint(-0.5, 0.5, 0), Intersection{}).Normalize()
+	right := ridge.localNormalAt(tuple.NewPoint(-0.9, 0.1, 0), Intersection{}).Normalize()
+	if left.X >= 0 || right.X >= 0 || left.X <= right.X {
+		t.Errorf("Heightfield normals should lean away from the ridge less and less towards it: %s %s", left, right)
+	}
+}
+
+func TestHeightsFromImage(t *testing.T) {
+	image := canvas.New(2, 2)
+	image[0][0] = color.New(1, 1, 1)
+	image[1][0] = color.New(0.3, 0.6, 0.9)
+	image[0][1] = color.New(0, 0, 0)
+	image[1][1] = color.New(0.2, 0.2, 0.2)
+
+	// the top row of the image is at the far end, z = 1.
+	expected := [][]float64{{0, 1}, {0.2, 0.6}}
+	result := HeightsFromImage(image)
+	for x := range expected {
+		for z := range expected[x] {
+			if !utils.FloatEquals(result[x][z], expected[x][z]) {
+				t.Errorf("height at %d, %d: %f, expected: %f", x, z, result[x][z], expected[x][z])
+			}
+		}
+	}
+}
+
+func TestBoundingBoxForHeightfield(t *testing.T) {
+	shape := NewHeightfield([][]float64{{0.2, 0.4}, {0.9, 0.3}})
+	shape.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(-1, 0.2, -1), tuple.NewPoint(1, 0.9, 1))
+
+	box := shape.BoundingBox()
+	if !box.Min.Equal(expected.Min) || !box.Max.Equal(expected.Max) {
+		t.Errorf("Heightfield bounding box:\nresult: %s %s\nexpected: %s %s", box.Min, box.Max, expected.Min, expected.Max)
+	}
+}
@@ -56,8 +56,26 @@ func (m *Model) BoundingBox() *BoundingBox {
 	return m.group.BoundingBox()
 }
 
-func (m *Model) Divide(threshold int) {
-	m.group.Divide(threshold)
+// BuildBVH organises the triangles into a bounding volume hierarchy.
+func (m *Model) BuildBVH(leafSize int) {
+	m.group.BuildBVH(leafSize)
+}
+
+// BVH returns the hierarchy of the triangles, nil if it was not built.
+func (m *Model) BVH() *BVH {
+	return m.group.bvh
+}
+
+// Triangles returns the faces of the model.
+func (m *Model) Triangles() []*Triangle {
+	children := m.group.Children()
+	triangles := make([]*Triangle, 0, len(children))
+	for _, child := range children {
+		if t, ok := child.(*Triangle); ok {
+			triangles = append(triangles, t)
+		}
+	}
+	return triangles
 }
 
 func (m *Model) localNormalAt(_point tuple.Tuple, _hit Intersection) tuple.Tuple {
@@ -68,6 +86,10 @@ func (m *Model) localIntersect(r *ray.Ray) Intersections {
 	return m.group.localIntersect(r)
 }
 
+func (m *Model) localIntersectAll(r *ray.Ray) Intersections {
+	return m.group.localIntersectAll(r)
+}
+
 func (m *Model) Parent() Shape {
 	return m.parent
 }
@@ -84,8 +106,9 @@ func (m *Model) parse(input string) {
 	m.group = *NewGroup()
 	vertices := parseVertices(input)
 	normals := parseNormals(input)
+	textureCoords := parseTextureCoords(input)
 
-	faces := parseFaces(input, vertices, normals)
+	faces := parseFaces(input, vertices, normals, textureCoords)
 
 	// try to make it work and see if it speeds things up.
 	// group.AddChild(faces.(*Shape)...)
@@ -128,41 +151,72 @@ func parseNormals(input string) []tuple.Tuple {
 	return normals
 }
 
-func parseFaces(input string, vertices, normals []tuple.Tuple) (faces []*Triangle) {
+// Texture coordinates are stored as points, X is u and Y is v.
+func parseTextureCoords(input string) []tuple.Tuple {
+	r := regexp.MustCompile("(?m)^vt .*\n")
+	lines := r.FindAllString(input, -1)
+	coords := []tuple.Tuple{
+		tuple.NewPoint(0, 0, 0), // index is 1 based
+	}
+	for i := 0; i < len(lines); i++ {
+		split := strings.Fields(lines[i])
+		u, _ := strconv.ParseFloat(split[1], 64)
+		var v float64
+		if len(split) > 2 {
+			v, _ = strconv.ParseFloat(split[2], 64)
+		}
+		coords = append(coords, tuple.NewPoint(u, v, 0))
+	}
+	return coords
+}
+
+func parseFaces(input string, vertices, normals, textureCoords []tuple.Tuple) (faces []*Triangle) {
 	r := regexp.MustCompile("(?m)^f.*\n")
 	faceLines := r.FindAllString(input, -1)
 	for i := 0; i < len(faceLines); i++ {
 		indexes := convertLinesToIndexes(faceLines[i])
+		// the texture coordinates are used only when every corner has a valid one.
+		textured := true
+		for _, index := range indexes {
+			textured = textured && index[2] > 0 && index[2] < len(textureCoords)
+		}
 
 		// fan triangulation
 		for i := 0; i < len(indexes)-2; i++ {
+			var face *Triangle
 			if indexes[0][1] != 0 {
-				face := NewSmoothTriangle(vertices[indexes[0][0]], vertices[indexes[i+1][0]], vertices[indexes[i+2][0]], normals[indexes[0][1]], normals[indexes[i+1][1]], normals[indexes[i+2][1]])
-				faces = append(faces, face)
+				face = NewSmoothTriangle(vertices[indexes[0][0]], vertices[indexes[i+1][0]], vertices[indexes[i+2][0]], normals[indexes[0][1]], normals[indexes[i+1][1]], normals[indexes[i+2][1]])
 			} else {
-				face := NewTriangle(vertices[indexes[0][0]], vertices[indexes[i+1][0]], vertices[indexes[i+2][0]])
-				faces = append(faces, face)
+				face = NewTriangle(vertices[indexes[0][0]], vertices[indexes[i+1][0]], vertices[indexes[i+2][0]])
 			}
+			if textured {
+				face.T1 = textureCoords[indexes[0][2]]
+				face.T2 = textureCoords[indexes[i+1][2]]
+				face.T3 = textureCoords[indexes[i+2][2]]
+			}
+			faces = append(faces, face)
 		}
 	}
 	return faces
 }
 
+// Returns the vertex, normal and texture coordinate indexes of the corners of a face.
+// The corners are written as v, v/vt, v//vn or v/vt/vn, the missing indexes are 0.
 func convertLinesToIndexes(line string) (indexes [][]int) {
 	stripNewline := strings.Replace(line, "\n", "", 1)
 	split := strings.Split(stripNewline, " ")
-	withNormal, _ := regexp.MatchString(`\d+\/\d*\/\d+`, split[1])
 	// skipping the first element as it's the type char e.g. f
 	for i := 1; i < len(split); i++ {
-		if withNormal {
-			n := strings.Split(split[i], "/")
-			vertex, _ := strconv.Atoi(n[0])
-			normal, _ := strconv.Atoi(n[2])
-			indexes = append(indexes, []int{vertex, normal})
-		} else {
-			vertex, _ := strconv.Atoi(split[i])
-			indexes = append(indexes, []int{vertex, 0})
+		n := strings.Split(split[i], "/")
+		vertex, _ := strconv.Atoi(n[0])
+		var normal, texture int
+		if len(n) > 1 {
+			texture, _ = strconv.Atoi(n[1])
+		}
+		if len(n) > 2 {
+			normal, _ = strconv.Atoi(n[2])
 		}
+		indexes = append(indexes, []int{vertex, normal, texture})
 	}
 
 	return indexes
@@ -66,7 +66,7 @@ f 1 3 4
 		tuple.NewPoint(1, 0, 0),
 		tuple.NewPoint(1, 1, 0),
 	}
-	vertices := parseFaces(input, points, []tuple.Tuple{})
+	vertices := parseFaces(input, points, []tuple.Tuple{}, []tuple.Tuple{})
 
 	assertFace(vertices[0], points[1], points[2], points[3], t)
 	assertFace(vertices[1], points[1], points[3], points[4], t)
@@ -95,7 +95,7 @@ f 1/0/3 2/102/1 3/14/2
 		tuple.NewVector(1, 0, 0),
 		tuple.NewVector(0, 1, 0),
 	}
-	faces := parseFaces(input, vertices, normals)
+	faces := parseFaces(input, vertices, normals, []tuple.Tuple{})
 
 	assertFace(faces[0], vertices[1], vertices[2], vertices[3], t)
 	assertFace(faces[1], vertices[1], vertices[2], vertices[3], t)
@@ -120,7 +120,7 @@ f 1 2 3 4 5
 		tuple.NewPoint(1, 1, 0),
 		tuple.NewPoint(0, 2, 0),
 	}
-	vertices := parseFaces(input, points, []tuple.Tuple{})
+	vertices := parseFaces(input, points, []tuple.Tuple{}, []tuple.Tuple{})
 	assertFace(vertices[0], points[1], points[2], points[3], t)
 	assertFace(vertices[1], points[1], points[3], points[4], t)
 	assertFace(vertices[2], points[1], points[4], points[5], t)
@@ -149,3 +149,68 @@ func assertFaceNormal(face *Triangle, n1, n2, n3 tuple.Tuple, t *testing.T) {
 		t.Errorf("Incorrect parsing. expected vertex normal N3 to be \n%s \n got %s", n3, face.N3)
 	}
 }
+
+func TestModelTriangles(t *testing.T) {
+	input := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+f 1 2 3 4
+`
+
+	m := NewModel(input)
+	triangles := m.Triangles()
+	if len(triangles) != 2 {
+		t.Fatalf("expected 2 triangles, got %d", len(triangles))
+	}
+	for _, tri := range triangles {
+		if tri.Parent() != m {
+			t.Errorf("expected the triangles to belong to the model")
+		}
+	}
+}
+
+func TestParseTextureCoords(t *testing.T) {
+	input := `v 0 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+vt 0.5 1
+vt 0 0
+vt 1 0 0
+vt 1 1
+vn 0 0 1
+f 1/1 2/2 3/3
+f 1/1/1 3/3/1 4/4/1
+f 1 2 3
+`
+
+	m := NewModel(input)
+	triangles := m.Triangles()
+	var tests = []struct {
+		face       *Triangle
+		t1, t2, t3 tuple.Tuple
+	}{
+		{
+			face: triangles[0],
+			t1:   tuple.NewPoint(0.5, 1, 0),
+			t2:   tuple.NewPoint(0, 0, 0),
+			t3:   tuple.NewPoint(1, 0, 0),
+		},
+		{
+			face: triangles[1],
+			t1:   tuple.NewPoint(0.5, 1, 0),
+			t2:   tuple.NewPoint(1, 0, 0),
+			t3:   tuple.NewPoint(1, 1, 0),
+		},
+		{
+			face: triangles[2],
+		},
+	}
+
+	for i, test := range tests {
+		if test.face.T1 != test.t1 || test.face.T2 != test.t2 || test.face.T3 != test.t3 {
+			t.Errorf("face %d: expected texture coordinates %s %s %s, got %s %s %s", i, test.t1, test.t2, test.t3, test.face.T1, test.face.T2, test.face.T3)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+// Quad is a finite piece of a plane, a square from -1 to 1 on the x and z axes, facing up.
+// Scaled it makes a rectangle, e.g. a floor tile or a card behind an area light.
+type Quad struct {
+	transform   matrix.Matrix
+	material    *materials.Material
+	parent      Shape
+	boundingBox *BoundingBox
+}
+
+func NewQuad() *Quad {
+	return &Quad{
+		transform:   matrix.DefaultTransform(),
+		material:    materials.DefaultMaterial(),
+		boundingBox: DefaultBoundingBox(),
+	}
+}
+
+func (s *Quad) String() string {
+	return fmt.Sprintf("Quad(transform: %s, material: %s)", s.transform, s.material)
+}
+
+func (s *Quad) Parent() Shape {
+	return s.parent
+}
+
+func (s *Quad) SetParent(other Shape) {
+	s.parent = other
+}
+
+func (s *Quad) SetTransform(transform matrix.Matrix) {
+	s.transform = transform
+}
+
+func (s *Quad) SetMaterial(mat *materials.Material) {
+	s.material = mat
+}
+
+func (s *Quad) Material() *materials.Material {
+	return s.material
+}
+
+func (s *Quad) Transform() matrix.Matrix {
+	return s.transform
+}
+
+func (s *Quad) CalculateBoundingBox() {
+	s.boundingBox.Min = tuple.NewPoint(-1, 0, -1)
+	s.boundingBox.Max = tuple.NewPoint(1, 0, 1)
+
+	TransformBoundingBox(s.boundingBox, s.Transform())
+}
+
+func (s *Quad) BoundingBox() *BoundingBox {
+	return s.boundingBox
+}
+
+func (s *Quad) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	return tuple.NewVector(0, 1, 0)
+}
+
+func (s *Quad) localIntersect(r *ray.Ray) Intersections {
+	if math.Abs(r.Direction.Y) < utils.EPSILON {
+		return Intersections{}
+	}
+
+	t := -r.Origin.Y / r.Direction.Y
+	x := r.Origin.X + t*r.Direction.X
+	z := r.Origin.Z + t*r.Direction.Z
+	if math.Abs(x) > 1 || math.Abs(z) > 1 {
+		return Intersections{}
+	}
+
+	return Intersections{
+		NewIntersection(t, s),
+	}
+}
@@ -0,0 +1,62 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+func TestQuadLocalIntersect(t *testing.T) {
+	quad := NewQuad()
+	var tests = []struct {
+		ray      *ray.Ray
+		expected Intersections
+	}{
+		{
+			ray:      ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{NewIntersection(1, quad)},
+		},
+		// the corner of the quad is outside of the unit circle
+		{
+			ray:      ray.New(tuple.NewPoint(0.9, 1, -0.9), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{NewIntersection(1, quad)},
+		},
+		{
+			ray:      ray.New(tuple.NewPoint(1.1, 1, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{},
+		},
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)),
+			expected: Intersections{},
+		},
+	}
+
+	for _, test := range tests {
+		testIntersection(t, quad, test.ray, test.expected)
+	}
+}
+
+func TestQuadLocalNormalAt(t *testing.T) {
+	quad := NewQuad()
+	for _, point := range []tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(1, 0, -1)} {
+		if result := quad.localNormalAt(point, Intersection{}); !result.Equal(tuple.NewVector(0, 1, 0)) {
+			t.Errorf("Quad normal at %s: %s", point, result)
+		}
+	}
+}
+
+func TestBoundingBoxForQuad(t *testing.T) {
+	// a rectangle standing on its side
+	quad := NewQuad()
+	quad.SetTransform(matrix.Multiply(matrix.RotationX(math.Pi/2), matrix.Scaling(2, 1, 0.5)))
+	quad.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(-2, -0.5, 0), tuple.NewPoint(2, 0.5, 0))
+
+	box := quad.BoundingBox()
+	if !box.Min.Equal(expected.Min) || !box.Max.Equal(expected.Max) {
+		t.Errorf("Quad bounding box:\nresult: %s %s\nexpected: %s %s", box.Min, box.Max, expected.Min, expected.Max)
+	}
+}
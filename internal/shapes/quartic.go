@@ -0,0 +1,133 @@
+package shapes
+
+import (
+	"math"
+	"sort"
+)
+
+// coefficients closer to zero than this are treated as zero by the solvers.
+const rootEpsilon = 1e-9
+
+// solveQuartic returns the real roots of c4x^4 + c3x^3 + c2x^2 + c1x + c0 in ascending order.
+// Ferrari's method, following Jochen Schwarze's solver in Graphics Gems. The closed form loses
+// precision, so the roots are polished with a few Newton steps on the original polynomial.
+func solveQuartic(c4, c3, c2, c1, c0 float64) []float64 {
+	if math.Abs(c4) < rootEpsilon {
+		roots := solveCubic(c3, c2, c1, c0)
+		sort.Float64s(roots)
+		return roots
+	}
+
+	// normal form: x^4 + ax^3 + bx^2 + cx + d
+	a, b, c, d := c3/c4, c2/c4, c1/c4, c0/c4
+
+	// substituting x = y - a/4 eliminates the cubic term: y^4 + py^2 + qy + r
+	sqA := a * a
+	p := -3.0/8*sqA + b
+	q := 1.0/8*sqA*a - 1.0/2*a*b + c
+	r := -3.0/256*sqA*sqA + 1.0/16*sqA*b - 1.0/4*a*c + d
+
+	var roots []float64
+	if math.Abs(r) < rootEpsilon {
+		// no absolute term: y(y^3 + py + q) = 0
+		roots = append(solveCubic(1, 0, p, q), 0)
+	} else {
+		// one root of the resolvent cubic splits the quartic into two quadratics.
+		z := solveCubic(1, -1.0/2*p, -r, 1.0/2*r*p-1.0/8*q*q)[0]
+
+		u, v := z*z-r, 2*z-p
+		if u < -rootEpsilon || v < -rootEpsilon {
+			return []float64{}
+		}
+		u, v = math.Sqrt(math.Max(u, 0)), math.Sqrt(math.Max(v, 0))
+		if q < 0 {
+			v = -v
+		}
+
+		roots = append(solveQuadratic(1, v, z-u), solveQuadratic(1, -v, z+u)...)
+	}
+
+	for i := range roots {
+		roots[i] = polish(roots[i]-a/4, c4, c3, c2, c1, c0)
+	}
+	sort.Float64s(roots)
+	return roots
+}
+
+// solveCubic returns the real roots of c3x^3 + c2x^2 + c1x + c0, Cardano's method.
+func solveCubic(c3, c2, c1, c0 float64) []float64 {
+	if math.Abs(c3) < rootEpsilon {
+		return solveQuadratic(c2, c1, c0)
+	}
+
+	// normal form: x^3 + ax^2 + bx + c
+	a, b, c := c2/c3, c1/c3, c0/c3
+
+	// substituting x = y - a/3 eliminates the quadratic term: y^3 + 3py + 2q
+	sqA := a * a
+	p := 1.0 / 3 * (-1.0/3*sqA + b)
+	q := 1.0 / 2 * (2.0/27*a*sqA - 1.0/3*a*b + c)
+	cbP := p * p * p
+	discriminant := q*q + cbP
+
+	var roots []float64
+	switch {
+	case math.Abs(discriminant) < rootEpsilon:
+		if math.Abs(q) < rootEpsilon {
+			// a triple root
+			roots = []float64{0}
+		} else {
+			// a single and a double root
+			u := math.Cbrt(-q)
+			roots = []float64{2 * u, -u}
+		}
+	case discriminant < 0:
+		// three real roots
+		phi := 1.0 / 3 * math.Acos(-q/math.Sqrt(-cbP))
+		t := 2 * math.Sqrt(-p)
+		roots = []float64{
+			t * math.Cos(phi),
+			-t * math.Cos(phi+math.Pi/3),
+			-t * math.Cos(phi-math.Pi/3),
+		}
+	default:
+		// one real root
+		sqrtD := math.Sqrt(discriminant)
+		roots = []float64{math.Cbrt(sqrtD-q) - math.Cbrt(sqrtD+q)}
+	}
+
+	for i := range roots {
+		roots[i] -= a / 3
+	}
+	return roots
+}
+
+// solveQuadratic returns the real roots of c2x^2 + c1x + c0.
+func solveQuadratic(c2, c1, c0 float64) []float64 {
+	if math.Abs(c2) < rootEpsilon {
+		if math.Abs(c1) < rootEpsilon {
+			return []float64{}
+		}
+		return []float64{-c0 / c1}
+	}
+
+	discriminant := c1*c1 - 4*c2*c0
+	if discriminant < 0 {
+		return []float64{}
+	}
+	sqrtD := math.Sqrt(discriminant)
+	return []float64{(-c1 - sqrtD) / (2 * c2), (-c1 + sqrtD) / (2 * c2)}
+}
+
+// Newton's method, stops early when the derivative vanishes e.g. at a double root.
+func polish(x, c4, c3, c2, c1, c0 float64) float64 {
+	for i := 0; i < 4; i++ {
+		f := (((c4*x+c3)*x+c2)*x+c1)*x + c0
+		df := ((4*c4*x+3*c3)*x+2*c2)*x + c1
+		if math.Abs(df) < rootEpsilon {
+			break
+		}
+		x -= f / df
+	}
+	return x
+}
@@ -0,0 +1,55 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestSolveQuartic(t *testing.T) {
+	var tests = []struct {
+		coefficients [5]float64
+		expected     []float64
+	}{
+		// (x-1)(x-2)(x-3)(x-4)
+		{
+			coefficients: [5]float64{1, -10, 35, -50, 24},
+			expected:     []float64{1, 2, 3, 4},
+		},
+		// (x²+1)(x-2)(x+3)
+		{
+			coefficients: [5]float64{1, 1, -5, 1, -6},
+			expected:     []float64{-3, 2},
+		},
+		// x^4 + 1 has no real roots
+		{
+			coefficients: [5]float64{1, 0, 0, 0, 1},
+			expected:     []float64{},
+		},
+		// no absolute term: x(x-1)(x+1)(x-2) scaled by 2
+		{
+			coefficients: [5]float64{2, -4, -2, 4, 0},
+			expected:     []float64{-1, 0, 1, 2},
+		},
+		// falls back to the cubic: (x-1)(x-2)(x-3)
+		{
+			coefficients: [5]float64{0, 1, -6, 11, -6},
+			expected:     []float64{1, 2, 3},
+		},
+	}
+
+	for _, test := range tests {
+		c := test.coefficients
+		result := solveQuartic(c[0], c[1], c[2], c[3], c[4])
+		if len(result) != len(test.expected) {
+			t.Errorf("roots of %v:\nresult: %v\nexpected: %v", c, result, test.expected)
+			continue
+		}
+		for i := range result {
+			if !utils.FloatEquals(result[i], test.expected[i]) {
+				t.Errorf("roots of %v:\nresult: %v\nexpected: %v", c, result, test.expected)
+				break
+			}
+		}
+	}
+}
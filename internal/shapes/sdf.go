@@ -0,0 +1,154 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/sdf"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+const (
+	sdfMaxSteps    = 512
+	sdfHitDistance = 1e-6 // closer than this to the surface counts as a hit.
+	sdfMaxDistance = 1e3  // unbounded fields are marched this far.
+	sdfGradientH   = 1e-6
+)
+
+// SDF is a shape whose surface is defined by a signed distance function. It's intersected by sphere tracing:
+// the ray is advanced by the distance to the surface, which can't skip over it, until it gets close enough.
+type SDF struct {
+	transform   matrix.Matrix
+	material    *materials.Material
+	Field       sdf.Field
+	parent      Shape
+	boundingBox *BoundingBox
+}
+
+func NewSDF(field sdf.Field) *SDF {
+	return &SDF{
+		transform:   matrix.DefaultTransform(),
+		material:    materials.DefaultMaterial(),
+		Field:       field,
+		boundingBox: DefaultBoundingBox(),
+	}
+}
+
+func (s *SDF) String() string {
+	return fmt.Sprintf("SDF(min: %s, max: %s, transform: %s, material: %s)", s.Field.Min, s.Field.Max, s.transform, s.material)
+}
+
+func (s *SDF) Parent() Shape {
+	return s.parent
+}
+
+func (s *SDF) SetParent(other Shape) {
+	s.parent = other
+}
+
+func (s *SDF) SetTransform(transform matrix.Matrix) {
+	s.transform = transform
+}
+
+func (s *SDF) SetMaterial(mat *materials.Material) {
+	s.material = mat
+}
+
+func (s *SDF) Material() *materials.Material {
+	return s.material
+}
+
+func (s *SDF) Transform() matrix.Matrix {
+	return s.transform
+}
+
+func (s *SDF) CalculateBoundingBox() {
+	s.boundingBox.Min = s.Field.Min
+	s.boundingBox.Max = s.Field.Max
+
+	// The matrix transformation would result in Inf x 0 = NaN
+	if s.boundingBox.bounded() {
+		TransformBoundingBox(s.boundingBox, s.Transform())
+	}
+}
+
+func (s *SDF) BoundingBox() *BoundingBox {
+	return s.boundingBox
+}
+
+// The normal is the gradient of the distance, estimated with central differences.
+func (s *SDF) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	d := s.Field.Distance
+	return tuple.NewVector(
+		d(tuple.NewPoint(point.X+sdfGradientH, point.Y, point.Z))-d(tuple.NewPoint(point.X-sdfGradientH, point.Y, point.Z)),
+		d(tuple.NewPoint(point.X, point.Y+sdfGradientH, point.Z))-d(tuple.NewPoint(point.X, point.Y-sdfGradientH, point.Z)),
+		d(tuple.NewPoint(point.X, point.Y, point.Z+sdfGradientH))-d(tuple.NewPoint(point.X, point.Y, point.Z-sdfGradientH)),
+	)
+}
+
+// Marches through the bounds of the field and returns every crossing of the surface, both entering and leaving.
+// Bounded fields are marched from where the ray enters the bounds, so the crossings behind the origin are found too.
+func (s *SDF) localIntersect(r *ray.Ray) Intersections {
+	bounds := aABBIntersect(s, r, s.Field.Min, s.Field.Max)
+	if len(bounds) == 0 {
+		return Intersections{}
+	}
+
+	// the distances are measured in space, t is measured in the length of the direction.
+	speed := r.Direction.Magnitude()
+	if speed == 0 {
+		return Intersections{}
+	}
+	start, end := bounds[0].t, bounds[1].t
+	// an unbounded field is only marched in front of the origin.
+	if start < -sdfMaxDistance/speed {
+		start = 0
+	}
+	end = math.Min(end, start+sdfMaxDistance/speed)
+
+	xs := Intersections{}
+	onSurface := false
+	t := start
+	for step := 0; step < sdfMaxSteps && t <= end; step++ {
+		distance := math.Abs(s.distanceAt(r, t))
+		if distance >= sdfHitDistance {
+			onSurface = false
+			t += distance / speed
+			continue
+		}
+
+		// the ray stays close to the surface for a few steps after a hit, it's the same crossing.
+		if !onSurface {
+			xs = append(xs, NewIntersection(s.refine(r, t), s))
+			onSurface = true
+		}
+		t += 2 * sdfHitDistance / speed
+	}
+	return xs
+}
+
+func (s *SDF) distanceAt(r *ray.Ray, t float64) float64 {
+	return s.Field.Distance(r.Position(t))
+}
+
+// Newton's method along the ray moves the hit onto the surface. The marching stops near the surface,
+// that would be too far for the offset of the shadow and reflection rays.
+func (s *SDF) refine(r *ray.Ray, t float64) float64 {
+	h := sdfGradientH / r.Direction.Magnitude()
+	refined := t
+	for i := 0; i < 4; i++ {
+		slope := (s.distanceAt(r, refined+h) - s.distanceAt(r, refined-h)) / (2 * h)
+		// a grazing ray, the surface is too flat along it to converge.
+		if math.Abs(slope) < sdfHitDistance {
+			return t
+		}
+		refined -= s.distanceAt(r, refined) / slope
+	}
+	if math.Abs(refined-t) > 10*h {
+		return t
+	}
+	return refined
+}
@@ -0,0 +1,133 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/sdf"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestSDFLocalIntersect(t *testing.T) {
+	sphere := NewSDF(sdf.Sphere(1))
+	repeated := NewSDF(sdf.Repeat(sdf.Sphere(1), tuple.NewVector(4, 0, 0)))
+
+	var tests = []struct {
+		shape    *SDF
+		ray      *ray.Ray
+		expected []float64
+	}{
+		// the same as a sphere
+		{
+			shape:    sphere,
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)),
+			expected: []float64{4, 6},
+		},
+		{
+			shape:    sphere,
+			ray:      ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 2)),
+			expected: []float64{2, 3},
+		},
+		{
+			shape:    sphere,
+			ray:      ray.New(tuple.NewPoint(0, 2, -5), tuple.NewVector(0, 0, 1)),
+			expected: []float64{},
+		},
+		// starting inside, the entry behind the origin is found too
+		{
+			shape:    sphere,
+			ray:      ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1)),
+			expected: []float64{-1, 1},
+		},
+		// every copy is hit
+		{
+			shape:    repeated,
+			ray:      ray.New(tuple.NewPoint(-5.5, 0, 0), tuple.NewVector(1, 0, 0)),
+			expected: []float64{0.5, 2.5, 4.5, 6.5, 8.5, 10.5},
+		},
+	}
+
+	for _, test := range tests {
+		result := test.shape.localIntersect(test.ray)
+		if test.shape == repeated {
+			// unbounded fields are marched far, only the first few copies are checked.
+			result = result[:min(len(result), len(test.expected))]
+		}
+		if len(result) != len(test.expected) {
+			t.Errorf("incorrect number of intersections:\n%s\nresult: %d. expected: %d", test.ray, len(result), len(test.expected))
+			continue
+		}
+		for i := range result {
+			if !utils.FloatEquals(result[i].t, test.expected[i]) {
+				t.Errorf("incorrect t of intersect:\n%s\nresult: %f. expected: %f", test.ray, result[i].t, test.expected[i])
+			}
+		}
+	}
+}
+
+func TestSDFOverPointDoesNotHitItself(t *testing.T) {
+	// a shadow ray starting just over the surface must not hit the surface it starts from.
+	shape := NewSDF(sdf.Union(sdf.Sphere(1), sdf.Torus(1, 0.3), 0.2))
+	origin := tuple.NewPoint(0.3, 2, -5)
+	r := ray.New(origin, tuple.Subtract(tuple.NewPoint(0.2, 0.1, 0), origin).Normalize())
+
+	xs := shape.localIntersect(r)
+	if len(xs) == 0 {
+		t.Fatalf("the ray should hit the shape")
+	}
+	point := r.Position(xs[0].t)
+	overPoint := tuple.Add(point, shape.localNormalAt(point, xs[0]).Normalize().Scalar(utils.EPSILON))
+
+	shadowRay := ray.New(overPoint, tuple.NewVector(0, 1, 0))
+	for _, x := range shape.localIntersect(shadowRay) {
+		if x.t > 0 {
+			t.Errorf("shadow ray hit the surface at %f", x.t)
+		}
+	}
+}
+
+func TestSDFLocalNormalAt(t *testing.T) {
+	shape := NewSDF(sdf.Sphere(1))
+	var tests = []struct {
+		point    tuple.Tuple
+		expected tuple.Tuple
+	}{
+		{
+			point:    tuple.NewPoint(1, 0, 0),
+			expected: tuple.NewVector(1, 0, 0),
+		},
+		{
+			point:    tuple.NewPoint(0, 0, -1),
+			expected: tuple.NewVector(0, 0, -1),
+		},
+		{
+			point:    tuple.NewPoint(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3),
+			expected: tuple.NewVector(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3),
+		},
+	}
+
+	for _, test := range tests {
+		result := shape.localNormalAt(test.point, Intersection{}).Normalize()
+		if !result.Equal(test.expected) {
+			t.Errorf("SDF normal: \nresult: \n%s. \nexpected: \n%s", result, test.expected)
+		}
+	}
+}
+
+func TestBoundingBoxForSDF(t *testing.T) {
+	shape := NewSDF(sdf.Translate(sdf.Sphere(1), tuple.NewVector(0, 2, 0)))
+	shape.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(-1, 1, -1), tuple.NewPoint(1, 3, 1))
+
+	for _, diff := range utils.Compare(shape.BoundingBox(), expected) {
+		t.Errorf("Mismatch: %s", diff)
+	}
+
+	repeated := NewSDF(sdf.Repeat(sdf.Sphere(1), tuple.NewVector(4, 0, 0)))
+	repeated.CalculateBoundingBox()
+	if box := repeated.BoundingBox(); !math.IsInf(box.Min.X, -1) || !math.IsInf(box.Max.X, 1) || box.Max.Y != 1 {
+		t.Errorf("repeated SDF bounding box: %s %s", box.Min, box.Max)
+	}
+}
@@ -4,6 +4,11 @@
 // - Plane
 // - Cube
 // - Cylinder
+// - Cone
+// - Torus
+// - Disk
+// - Quad
+// - Heightfield
 
 package shapes
 
@@ -32,10 +37,18 @@ type Shape interface {
 func ColorAt(scenePoint tuple.Tuple, shape Shape) color.Color {
 	// transform a point in scene(global) space to object(local) space
 	objectPoint := sceneToObject(scenePoint, shape)
-	invPatternTransform := shape.Material().Transform().Inverse()
-	patternPoint := tuple.Multiply(invPatternTransform, objectPoint)
+	return patternColorAt(shape.Material().Pattern(), objectPoint, shape)
+}
+
+// Returns the color of a pattern at a point in the object space of the shape.
+func patternColorAt(pattern *materials.Pattern, objectPoint tuple.Tuple, shape Shape) color.Color {
+	patternPoint := tuple.Multiply(pattern.Transform().Inverse(), objectPoint)
 
-	return shape.Material().ColorAt(patternPoint)
+	if pattern.UVMapped() {
+		u, v := uvAt(objectPoint, patternPoint, shape, pattern.Mapping())
+		return pattern.ColorAtUV(u, v)
+	}
+	return pattern.ColorAt(patternPoint)
 }
 
 func Intersect(s Shape, r *ray.Ray) Intersections {
@@ -47,12 +60,41 @@ func Intersect(s Shape, r *ray.Ray) Intersections {
 	return s.localIntersect(localRay)
 }
 
+// Shapes made of other shapes skip the intersections beyond the closest hit when they can.
+// They implement this to return every intersection instead.
+type allIntersector interface {
+	localIntersectAll(r *ray.Ray) Intersections
+}
+
+// IntersectAll returns every intersection of the ray with the shape, none is skipped.
+func IntersectAll(s Shape, r *ray.Ray) Intersections {
+	all, ok := s.(allIntersector)
+	if !ok {
+		return Intersect(s, r)
+	}
+
+	transform := s.Transform().Inverse()
+	origin := tuple.Multiply(transform, r.Origin)
+	direction := tuple.Multiply(transform, r.Direction)
+	return all.localIntersectAll(ray.New(origin, direction))
+}
+
+func intersect(s Shape, r *ray.Ray, all bool) Intersections {
+	if all {
+		return IntersectAll(s, r)
+	}
+	return Intersect(s, r)
+}
+
 // Calculates the normal vector on the surface of a shape at a given point (the hit).
 func NormalAt(scenePoint tuple.Tuple, shape Shape, hit Intersection) tuple.Tuple {
 	// transform a point in scene(global) space to object(local) space
 	localPoint := sceneToObject(scenePoint, shape)
 	// calculate the normal vector in object(local) space
 	localNormal := shape.localNormalAt(localPoint, hit)
+	if bump := shape.Material().Bump; bump != nil {
+		localNormal = bumpNormal(bump, localPoint, localNormal, shape)
+	}
 	// transform the normal vector in object(local) space to scene(global) space.
 	return objectToScene(localNormal, shape)
 }
@@ -67,6 +109,15 @@ func sceneToObject(p tuple.Tuple, s Shape) tuple.Tuple {
 	return result
 }
 
+// PointToScene transforms a point from the object space of the shape to scene space.
+func PointToScene(p tuple.Tuple, s Shape) tuple.Tuple {
+	p = tuple.Multiply(s.Transform(), p)
+	if s.Parent() != nil {
+		p = PointToScene(p, s.Parent())
+	}
+	return p
+}
+
 func objectToScene(v tuple.Tuple, s Shape) tuple.Tuple {
 	transposed := s.Transform().Inverse().Transpose()
 	normal := tuple.Multiply(transposed, v).ToVector().Normalize()
@@ -163,6 +163,23 @@ func TestSceneToObject(t *testing.T) {
 	}
 }
 
+func TestPointToScene(t *testing.T) {
+	// Converting a point from object to scene space, the inverse of sceneToObject
+	g1 := NewGroup()
+	g1.SetTransform(matrix.RotationY(math.Pi / 2))
+	g2 := NewGroup()
+	g2.SetTransform(matrix.Scaling(2, 2, 2))
+	g1.AddChild(g2)
+	s := NewSphere()
+	s.SetTransform(matrix.Translation(5, 0, 0))
+	g2.AddChild(s)
+	result := PointToScene(tuple.NewPoint(0, 0, -1), s)
+	expected := tuple.NewPoint(-2, 0, -10)
+	if !result.Equal(expected) {
+		t.Errorf("incorrect point conversion to scene space.\nexpected: %s\nresult: %s", expected, result)
+	}
+}
+
 func TestObjectToScene(t *testing.T) {
 	// Converting a normal from object to scene space
 	g1 := NewGroup()
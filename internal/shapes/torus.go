@@ -0,0 +1,107 @@
+package shapes
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/matrix"
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Torus is a ring around the y axis. MajorRadius is the distance of the center of the tube from the axis,
+// MinorRadius is the radius of the tube.
+type Torus struct {
+	transform                matrix.Matrix
+	material                 *materials.Material
+	MajorRadius, MinorRadius float64
+	parent                   Shape
+	boundingBox              *BoundingBox
+}
+
+func NewTorus() *Torus {
+	return &Torus{
+		transform:   matrix.DefaultTransform(),
+		material:    materials.DefaultMaterial(),
+		MajorRadius: 1,
+		MinorRadius: 0.25,
+		boundingBox: DefaultBoundingBox(),
+	}
+}
+
+func (s *Torus) String() string {
+	return fmt.Sprintf("Torus(major radius: %f, minor radius: %f, transform: %s, material: %s)", s.MajorRadius, s.MinorRadius, s.transform, s.material)
+}
+
+func (s *Torus) Parent() Shape {
+	return s.parent
+}
+
+func (s *Torus) SetParent(other Shape) {
+	s.parent = other
+}
+
+func (s *Torus) SetTransform(transform matrix.Matrix) {
+	s.transform = transform
+}
+
+func (s *Torus) SetMaterial(mat *materials.Material) {
+	s.material = mat
+}
+
+func (s *Torus) Material() *materials.Material {
+	return s.material
+}
+
+func (s *Torus) Transform() matrix.Matrix {
+	return s.transform
+}
+
+func (s *Torus) CalculateBoundingBox() {
+	outer := s.MajorRadius + s.MinorRadius
+	s.boundingBox.Min = tuple.NewPoint(-outer, -s.MinorRadius, -outer)
+	s.boundingBox.Max = tuple.NewPoint(outer, s.MinorRadius, outer)
+
+	TransformBoundingBox(s.boundingBox, s.Transform())
+}
+
+func (s *Torus) BoundingBox() *BoundingBox {
+	return s.boundingBox
+}
+
+// The normal points away from the closest point of the circle that runs through the center of the tube.
+func (s *Torus) localNormalAt(point tuple.Tuple, _hit Intersection) tuple.Tuple {
+	dist := math.Sqrt(math.Pow(point.X, 2) + math.Pow(point.Z, 2))
+	if dist == 0 {
+		return tuple.NewVector(0, 1, 0)
+	}
+
+	center := tuple.NewPoint(point.X*s.MajorRadius/dist, 0, point.Z*s.MajorRadius/dist)
+	return tuple.Subtract(point, center)
+}
+
+// The points of the torus satisfy (x²+y²+z² + R² - r²)² = 4R²(x²+z²), substituting the ray gives a quartic in t.
+func (s *Torus) localIntersect(r *ray.Ray) Intersections {
+	origin := r.Origin.ToVector()
+	sqMajor := math.Pow(s.MajorRadius, 2)
+
+	dd := tuple.Dot(r.Direction, r.Direction)
+	od := tuple.Dot(origin, r.Direction)
+	e := tuple.Dot(origin, origin) - sqMajor - math.Pow(s.MinorRadius, 2)
+	four := 4 * sqMajor
+
+	roots := solveQuartic(
+		dd*dd,
+		4*dd*od,
+		2*dd*e+4*od*od+four*math.Pow(r.Direction.Y, 2),
+		4*od*e+2*four*r.Origin.Y*r.Direction.Y,
+		e*e-four*(math.Pow(s.MinorRadius, 2)-math.Pow(r.Origin.Y, 2)),
+	)
+
+	xs := make(Intersections, len(roots))
+	for i, t := range roots {
+		xs[i] = NewIntersection(t, s)
+	}
+	return xs
+}
@@ -0,0 +1,97 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/ray"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestTorusLocalIntersect(t *testing.T) {
+	torus := NewTorus()
+	var tests = []struct {
+		ray      *ray.Ray
+		expected Intersections
+	}{
+		// through both sides of the ring
+		{
+			ray: ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)),
+			expected: Intersections{
+				NewIntersection(3.75, torus),
+				NewIntersection(4.25, torus),
+				NewIntersection(5.75, torus),
+				NewIntersection(6.25, torus),
+			},
+		},
+		// down through the tube
+		{
+			ray: ray.New(tuple.NewPoint(1, 5, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{
+				NewIntersection(4.75, torus),
+				NewIntersection(5.25, torus),
+			},
+		},
+		// a scaled direction
+		{
+			ray: ray.New(tuple.NewPoint(1, 5, 0), tuple.NewVector(0, -2, 0)),
+			expected: Intersections{
+				NewIntersection(2.375, torus),
+				NewIntersection(2.625, torus),
+			},
+		},
+		// through the hole
+		{
+			ray:      ray.New(tuple.NewPoint(0, 5, 0), tuple.NewVector(0, -1, 0)),
+			expected: Intersections{},
+		},
+		// above the ring
+		{
+			ray:      ray.New(tuple.NewPoint(0, 0.3, -5), tuple.NewVector(0, 0, 1)),
+			expected: Intersections{},
+		},
+	}
+
+	for _, test := range tests {
+		testIntersection(t, torus, test.ray, test.expected)
+	}
+}
+
+func TestTorusLocalNormalAt(t *testing.T) {
+	torus := NewTorus()
+	var tests = []struct {
+		point    tuple.Tuple
+		expected tuple.Tuple
+	}{
+		{
+			point:    tuple.NewPoint(1.25, 0, 0),
+			expected: tuple.NewVector(0.25, 0, 0),
+		},
+		{
+			point:    tuple.NewPoint(0, 0, -0.75),
+			expected: tuple.NewVector(0, 0, 0.25),
+		},
+		{
+			point:    tuple.NewPoint(1, 0.25, 0),
+			expected: tuple.NewVector(0, 0.25, 0),
+		},
+	}
+
+	for _, test := range tests {
+		if result := torus.localNormalAt(test.point, Intersection{}); !result.Equal(test.expected) {
+			t.Errorf("Torus normal: \nresult: \n%s. \nexpected: \n%s", result, test.expected)
+		}
+	}
+}
+
+func TestBoundingBoxForTorus(t *testing.T) {
+	torus := NewTorus()
+	torus.MajorRadius = 2
+	torus.MinorRadius = 0.5
+	torus.CalculateBoundingBox()
+	expected := NewBoundingBox(tuple.NewPoint(-2.5, -0.5, -2.5), tuple.NewPoint(2.5, 0.5, 2.5))
+
+	for _, diff := range utils.Compare(torus.BoundingBox(), expected) {
+		t.Errorf("Mismatch: %s", diff)
+	}
+}
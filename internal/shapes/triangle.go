@@ -15,6 +15,7 @@ import (
 type Triangle struct {
 	Model                                  Shape
 	P1, P2, P3, E1, E2, N1, N2, N3, Normal tuple.Tuple
+	T1, T2, T3                             tuple.Tuple // texture coordinates of the vertices, X is u and Y is v.
 	boundingBox                            *BoundingBox
 }
 
@@ -139,3 +140,21 @@ func (s *Triangle) smooth() bool {
 	emptyVector := tuple.NewVector(0, 0, 0)
 	return s.N1 != emptyVector || s.N2 != emptyVector || s.N3 != emptyVector
 }
+
+func (s *Triangle) textured() bool {
+	return s.T1 != tuple.Tuple{} || s.T2 != tuple.Tuple{} || s.T3 != tuple.Tuple{}
+}
+
+// Interpolates the texture coordinates of the vertices at a point on the triangle.
+func (s *Triangle) uvAt(point tuple.Tuple) (float64, float64) {
+	// the barycentric coordinates of the point, u is the weight of P2 and v is the weight of P3.
+	p := tuple.Subtract(point, s.P1)
+	d11, d12, d22 := tuple.Dot(s.E1, s.E1), tuple.Dot(s.E1, s.E2), tuple.Dot(s.E2, s.E2)
+	dp1, dp2 := tuple.Dot(p, s.E1), tuple.Dot(p, s.E2)
+	denominator := d11*d22 - d12*d12
+	u := (d22*dp1 - d12*dp2) / denominator
+	v := (d11*dp2 - d12*dp1) / denominator
+
+	t := tuple.Add(tuple.Add(s.T1.Scalar(1-u-v), s.T2.Scalar(u)), s.T3.Scalar(v))
+	return t.X, t.Y
+}
@@ -0,0 +1,37 @@
+package shapes
+
+import (
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+)
+
+// Returns the texture coordinates of a point. Vertex mapping uses the point in object space,
+// the other mappings the point in pattern space.
+func uvAt(objectPoint, patternPoint tuple.Tuple, shape Shape, mapping materials.Mapping) (float64, float64) {
+	if mapping == materials.AutoMapping {
+		mapping = defaultMapping(shape)
+	}
+	if mapping == materials.VertexMapping {
+		if t, ok := shape.(*Triangle); ok && t.textured() {
+			return t.uvAt(objectPoint)
+		}
+		mapping = materials.PlanarMapping
+	}
+	return mapping.UV(patternPoint)
+}
+
+// The mapping that fits the shape best.
+func defaultMapping(shape Shape) materials.Mapping {
+	switch shape.(type) {
+	case *Sphere:
+		return materials.SphericalMapping
+	case *Cube:
+		return materials.CubeMapping
+	case *Cylinder, *Cone:
+		return materials.CylindricalMapping
+	case *Triangle:
+		return materials.VertexMapping
+	default:
+		return materials.PlanarMapping
+	}
+}
@@ -0,0 +1,63 @@
+package shapes
+
+import (
+	"testing"
+
+	"github.com/kaizencodes/glimpse/internal/color"
+	"github.com/kaizencodes/glimpse/internal/materials"
+	"github.com/kaizencodes/glimpse/internal/tuple"
+	"github.com/kaizencodes/glimpse/internal/utils"
+)
+
+func TestColorAtWithUVPattern(t *testing.T) {
+	var tests = []struct {
+		shape    Shape
+		mapping  materials.Mapping
+		point    tuple.Tuple
+		expected color.Color
+	}{
+		// the sphere picks the spherical mapping
+		{shape: NewSphere(), mapping: materials.AutoMapping, point: tuple.NewPoint(0, 0, -1), expected: color.White()},
+		{shape: NewSphere(), mapping: materials.AutoMapping, point: tuple.NewPoint(1, 0, 0), expected: color.White()},
+		{shape: NewSphere(), mapping: materials.AutoMapping, point: tuple.NewPoint(0, 0, 1), expected: color.Black()},
+		// the mapping can be overridden
+		{shape: NewSphere(), mapping: materials.PlanarMapping, point: tuple.NewPoint(0, 0, 1), expected: color.White()},
+		// the cube picks the cube mapping, the right face is the third column
+		{shape: NewCube(), mapping: materials.AutoMapping, point: tuple.NewPoint(1, 0, 0), expected: color.Black()},
+		{shape: NewPlane(), mapping: materials.AutoMapping, point: tuple.NewPoint(0.75, 0, 0.25), expected: color.Black()},
+	}
+
+	for _, test := range tests {
+		test.shape.Material().SetPattern(materials.NewUVCheckerPattern(2, 1, color.White(), color.Black(), test.mapping))
+		if result := ColorAt(test.point, test.shape); !result.Equal(test.expected) {
+			t.Errorf("%s at %s: expected %s, got %s", test.shape, test.point, test.expected, result)
+		}
+	}
+}
+
+func TestTriangleUV(t *testing.T) {
+	triangle := NewTriangle(tuple.NewPoint(0, 1, 0), tuple.NewPoint(-1, 0, 0), tuple.NewPoint(1, 0, 0))
+	if triangle.textured() {
+		t.Errorf("expected a triangle without texture coordinates")
+	}
+
+	triangle.T1 = tuple.NewPoint(0.5, 1, 0)
+	triangle.T2 = tuple.NewPoint(0, 0, 0)
+	triangle.T3 = tuple.NewPoint(1, 0, 0)
+	var tests = []struct {
+		point                tuple.Tuple
+		expectedU, expectedV float64
+	}{
+		{point: tuple.NewPoint(0, 1, 0), expectedU: 0.5, expectedV: 1},
+		{point: tuple.NewPoint(-1, 0, 0), expectedU: 0, expectedV: 0},
+		{point: tuple.NewPoint(0, 0.5, 0), expectedU: 0.5, expectedV: 0.5},
+		{point: tuple.NewPoint(0.5, 0, 0), expectedU: 0.75, expectedV: 0},
+	}
+
+	for _, test := range tests {
+		u, v := uvAt(test.point, test.point, triangle, materials.AutoMapping)
+		if !utils.FloatEquals(u, test.expectedU) || !utils.FloatEquals(v, test.expectedV) {
+			t.Errorf("at %s: expected (%f, %f), got (%f, %f)", test.point, test.expectedU, test.expectedV, u, v)
+		}
+	}
+}
@@ -127,3 +127,15 @@ func Cross(a Tuple, b Tuple) Tuple {
 func Reflect(incoming, normal Tuple) Tuple {
 	return Subtract(incoming, normal.Scalar(2.0*Dot(incoming, normal)))
 }
+
+// Basis returns two unit vectors perpendicular to the normalized vector normal and each other.
+// Together with the normal they form a coordinate system, used to place samples around the normal.
+func Basis(normal Tuple) (u, v Tuple) {
+	helper := NewVector(1, 0, 0)
+	if math.Abs(normal.X) > 0.9 {
+		helper = NewVector(0, 1, 0)
+	}
+	u = Cross(normal, helper).Normalize()
+	v = Cross(normal, u)
+	return u, v
+}
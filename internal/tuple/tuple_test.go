@@ -358,3 +358,22 @@ func TestReflect(t *testing.T) {
 		}
 	}
 }
+
+func TestBasis(t *testing.T) {
+	normals := []Tuple{
+		NewVector(0, 1, 0),
+		NewVector(1, 0, 0),
+		NewVector(0, 0, -1),
+		NewVector(1, 2, 3).Normalize(),
+	}
+
+	for _, normal := range normals {
+		u, v := Basis(normal)
+		if !utils.FloatEquals(u.Magnitude(), 1) || !utils.FloatEquals(v.Magnitude(), 1) {
+			t.Errorf("Basis of %s expected unit vectors, got %s and %s", normal, u, v)
+		}
+		if !utils.FloatEquals(Dot(u, v), 0) || !utils.FloatEquals(Dot(u, normal), 0) || !utils.FloatEquals(Dot(v, normal), 0) {
+			t.Errorf("Basis of %s expected perpendicular vectors, got %s and %s", normal, u, v)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import "math"
+
+// ConcentricDisk maps the point (u, v) of the unit square onto the unit disk.
+// Grid cells keep similar areas and shapes, so stratified samples on the square stay stratified on the disk.
+// Shirley and Chiu, A Low Distortion Map Between Disk and Square.
+func ConcentricDisk(u, v float64) (x, y float64) {
+	a, b := 2*u-1, 2*v-1
+	if a == 0 && b == 0 {
+		return 0, 0
+	}
+
+	var r, phi float64
+	if math.Abs(a) > math.Abs(b) {
+		r, phi = a, math.Pi/4*(b/a)
+	} else {
+		r, phi = b, math.Pi/2-math.Pi/4*(a/b)
+	}
+	return r * math.Cos(phi), r * math.Sin(phi)
+}
+
+// CosineHemisphere maps the point (u, v) of the unit square onto the unit hemisphere around the z axis.
+// Directions close to the z axis are more likely, the density is proportional to the cosine of the angle
+// from the axis, which cancels out the cosine term of diffuse surfaces.
+func CosineHemisphere(u, v float64) (x, y, z float64) {
+	x, y = ConcentricDisk(u, v)
+	return x, y, math.Sqrt(math.Max(0, 1-x*x-y*y))
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConcentricDisk(t *testing.T) {
+	var tests = []struct {
+		u, v, x, y float64
+	}{
+		{u: 0.5, v: 0.5, x: 0, y: 0},
+		{u: 1, v: 0.5, x: 1, y: 0},
+		{u: 0.5, v: 1, x: 0, y: 1},
+		{u: 0, v: 0.5, x: -1, y: 0},
+		{u: 1, v: 1, x: math.Sqrt(2) / 2, y: math.Sqrt(2) / 2},
+	}
+
+	for _, test := range tests {
+		x, y := ConcentricDisk(test.u, test.v)
+		if !FloatEquals(x, test.x) || !FloatEquals(y, test.y) {
+			t.Errorf("ConcentricDisk(%f, %f) expected (%f, %f), got (%f, %f)", test.u, test.v, test.x, test.y, x, y)
+		}
+	}
+}
+
+func TestCosineHemisphere(t *testing.T) {
+	// The center of the square is the axis
+	if x, y, z := CosineHemisphere(0.5, 0.5); !FloatEquals(x, 0) || !FloatEquals(y, 0) || !FloatEquals(z, 1) {
+		t.Errorf("CosineHemisphere(0.5, 0.5) expected (0, 0, 1), got (%f, %f, %f)", x, y, z)
+	}
+
+	// The directions are normalized and stay above the surface
+	for i := 0; i <= 10; i++ {
+		for j := 0; j <= 10; j++ {
+			x, y, z := CosineHemisphere(float64(i)/10, float64(j)/10)
+			if !FloatEquals(x*x+y*y+z*z, 1) || z < 0 {
+				t.Errorf("CosineHemisphere(%f, %f) expected a unit vector above the surface, got (%f, %f, %f)", float64(i)/10, float64(j)/10, x, y, z)
+			}
+		}
+	}
+}
@@ -1,31 +1,42 @@
 /*
 Package main is the entry point for the glimpse ray tracer. It reads a scene
-file, renders the scene, and writes the result to a PPM file.
+file, renders the scene, and writes the result to an image file.
 */
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"runtime"
 	"runtime/pprof"
+	"strings"
 	"time"
 
+	"github.com/kaizencodes/glimpse/internal/canvas"
 	"github.com/kaizencodes/glimpse/internal/export"
 	"github.com/kaizencodes/glimpse/internal/renderer"
 	"github.com/kaizencodes/glimpse/internal/scenes/builder"
 	"github.com/kaizencodes/glimpse/internal/scenes/reader"
 )
 
-var filePath, outputPath, defaultOutputPath string
+var filePath, outputPath, defaultOutputPath, tileOrder string
+var workers, tileSize, samples int
+var timeout time.Duration
 
 func init() {
 	defaultOutputPath = "renders/render"
 
 	flag.StringVar(&filePath, "f", "", "Filepath for the yml describing the scene.")
-	flag.StringVar(&outputPath, "o", defaultOutputPath, "Output path where the render will be saved. Folder has to exist.")
+	flag.StringVar(&outputPath, "o", defaultOutputPath, "Output path where the render will be saved. Folder has to exist. The extension picks the format: .png, .ppm, .p3.ppm, .pfm or .exr.")
+	flag.IntVar(&workers, "workers", runtime.GOMAXPROCS(0), "Number of workers rendering tiles in parallel.")
+	flag.IntVar(&tileSize, "tile", renderer.DefaultTileSize, "Width and height of a render tile in pixels.")
+	flag.StringVar(&tileOrder, "order", "scanline", "Order in which the tiles are rendered: scanline, spiral or hilbert.")
+	flag.IntVar(&samples, "samples", 0, "Anti-aliasing, every pixel is sampled by samples×samples rays. Overrides the scene file.")
+	flag.DurationVar(&timeout, "timeout", 0, "Stop the render after the given duration, e.g. 10m. The partial image is still saved.")
 }
 
 const commandHelp = `Usage:
@@ -33,20 +44,28 @@ const commandHelp = `Usage:
 
 Description:
   Glimpse is a ray tracer. It reads a scene
-  file, renders the scene, and writes the result to a PPM file.
+  file, renders the scene, and writes the result to an image file.
 
 Options:
   -h		Show this help message and exit.
   -f		Filepath for the yml describing the scene.
   -o 		Output path where the render will be saved. Folder has to exist.
+    		The extension picks the format: .png, .ppm (binary), .p3.ppm (plain text), .pfm or .exr (floating point, exr holds the render passes as layers). Defaults to .png.
+  -workers	Number of workers rendering tiles in parallel. Defaults to the number of CPUs.
+  -tile		Width and height of a render tile in pixels.
+  -order	Order in which the tiles are rendered: scanline, spiral or hilbert.
+  -samples	Anti-aliasing, every pixel is sampled by samples×samples rays. Overrides the scene file.
+  -timeout	Stop the render after the given duration, e.g. 10m. The partial image is still saved.
 
 Examples:
   command -f /examples/marbles.yml
   command -f /examples/marbles.yml -o /renders/new_marble_render
+  command -f /examples/marbles.yml -o /renders/new_marble_render.pfm
 
 Additional Information:
   - The -o flag has a default value. It defaults to the renders folder.
-  - glimpse will append a timestamp and extension to the output file`
+  - Pressing Ctrl+C stops the render, the finished part of the image is still saved.
+  - glimpse will append a timestamp to the output file, and the .png extension if it has none`
 
 func main() {
 	start := time.Now()
@@ -67,6 +86,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the format is checked before rendering, so a typo doesn't waste a long render.
+	ext := export.Extension(outputPath)
+	outputBase := strings.TrimSuffix(outputPath, ext)
+	if ext == "" {
+		ext = ".png"
+	}
+	if _, err := export.WriterFor(ext); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	order, err := renderer.ParseTileOrder(tileOrder)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
 	config, err := reader.Read(filePath)
 	if err != nil {
 		fmt.Printf("The input file has the following error:\n\n %s\n", err.Error())
@@ -97,13 +133,75 @@ func main() {
 
 	cam, scene := builder.BuildScene(config)
 
-	img := renderer.Render(cam, scene)
+	// Ctrl+C stops the render gracefully, so the finished tiles can still be saved.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	opts := builder.BuildRenderOptions(config.Render)
+	post := builder.BuildPostProcess(config.Post)
+	opts.Workers = workers
+	opts.TileSize = tileSize
+	opts.Order = order
+	opts.Observer = renderer.NewConsoleObserver(os.Stdout)
+	if samples > 0 {
+		opts.Samples = samples
+	}
+
+	frame, err := renderer.RenderFrame(ctx, cam, scene, opts)
+	if err != nil {
+		fmt.Printf("Render stopped early, saving the partial image.\n")
+	}
+
+	fmt.Printf("Writing to file\n")
+
+	// high dynamic range formats keep the linear colors, the viewer can adjust them.
+	process := func(img canvas.Canvas) canvas.Canvas {
+		if export.HDR(ext) {
+			return img
+		}
+		return post.Apply(img)
+	}
 
-	fmt.Printf("\nWriting to file\n")
+	stamp := time.Now().Format(time.RFC3339Nano)
+	if export.Layered(ext) && len(opts.Passes) > 0 {
+		// the passes are layers of the same file.
+		layers := []export.Layer{{Canvas: process(frame.Color)}}
+		for _, pass := range opts.Passes {
+			layers = append(layers, export.Layer{Name: pass.String(), Canvas: frame.Passes[pass]})
+		}
+		if err := export.SaveLayers(fmt.Sprintf("%s-%s%s", outputBase, stamp, ext), layers); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		if err := export.Save(fmt.Sprintf("%s-%s%s", outputBase, stamp, ext), process(frame.Color)); err != nil {
+			log.Fatal(err)
+		}
+		for _, pass := range opts.Passes {
+			img := frame.Passes[pass]
+			// only the passes holding light look like the image, the others are data.
+			if pass.Lighting() {
+				img = process(img)
+			} else if !export.HDR(ext) {
+				// the data would be clipped to 0-1, only a normalised picture of it fits.
+				log.Printf("Warning: %s pass normalised to fit %s, use .pfm or .exr to keep the values", pass, ext)
+				img = pass.Normalize(img)
+			}
+			if err := export.Save(fmt.Sprintf("%s-%s-%s%s", outputBase, stamp, pass, ext), img); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
 
-	if err := os.WriteFile(fmt.Sprintf(outputPath+"-%s.ppm", time.Now().Format(time.RFC3339Nano)), export.Export(img), 0666); err != nil {
-		fmt.Printf("%e\n", err)
-		log.Fatal(err)
+	// the heatmap shows where adaptive sampling spent its rays.
+	if config.Render.Adaptive.Heatmap {
+		if err := export.Save(fmt.Sprintf("%s-%s-samples%s", outputBase, stamp, ext), frame.SampleHeatmap()); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	elapsed := time.Since(start)